@@ -31,7 +31,71 @@ var doc = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/group-create": {
+        "/v1/audit": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Lists the audit events recorded for mutating API calls made on the team, optionally limited to a time range",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Audit"
+                ],
+                "summary": "List the audit events of a team",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Canonical **slug** of the GitHub team",
+                        "name": "team",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only list events recorded at or after this RFC 3339 timestamp",
+                        "name": "since",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only list events recorded before this RFC 3339 timestamp",
+                        "name": "until",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/apis.JSONResultSuccess"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "Code": {
+                                            "type": "integer"
+                                        },
+                                        "Response": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/apis.AuditEvent"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/group-create": {
             "post": {
                 "security": [
                     {
@@ -54,6 +118,44 @@ var doc = `{
                         "in": "query",
                         "required": true
                     },
+                    {
+                        "type": "string",
+                        "description": "Suffix of the runner group of the team, selects its default runner group when unset",
+                        "name": "group",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Validate the request and return the change it would make without making it",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "selected",
+                            "private",
+                            "all"
+                        ],
+                        "type": "string",
+                        "description": "Visibility of the runner group, defaults to selected",
+                        "name": "visibility",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Allow public repositories to use the runner group, defaults to false",
+                        "name": "allowsPublicRepositories",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "description": "Comma-seperated list of workflows the runner group is restricted to",
+                        "name": "workflows",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
                         "description": "Authorization token",
@@ -87,7 +189,7 @@ var doc = `{
                 }
             }
         },
-        "/group-delete": {
+        "/v1/group-delete": {
             "delete": {
                 "security": [
                     {
@@ -109,6 +211,18 @@ var doc = `{
                         "name": "team",
                         "in": "query",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Suffix of the runner group of the team, selects its default runner group when unset",
+                        "name": "group",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Validate the request and return the change it would make without making it",
+                        "name": "dry_run",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -136,7 +250,7 @@ var doc = `{
                 }
             }
         },
-        "/group-list": {
+        "/v1/group-list": {
             "get": {
                 "security": [
                     {
@@ -158,6 +272,12 @@ var doc = `{
                         "name": "team",
                         "in": "query",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Suffix of the runner group of the team, selects its default runner group when unset",
+                        "name": "group",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -185,21 +305,21 @@ var doc = `{
                 }
             }
         },
-        "/repos-add": {
+        "/v1/group-update": {
             "patch": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Adds new repositories to an existing GitHub Actions organization named with the team slug",
+                "description": "Updates the visibility, public repository access and workflow restrictions of the runner group named with the team slug. Settings that are not passed are left unchanged, and an empty workflows parameter lifts the workflow restriction.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Repos"
+                    "Groups"
                 ],
-                "summary": "Add new repositories to an existing GitHub Actions organization runner group",
+                "summary": "Update the settings of an existing GitHub Action organization Runner Group",
                 "parameters": [
                     {
                         "type": "string",
@@ -208,15 +328,37 @@ var doc = `{
                         "in": "query",
                         "required": true
                     },
+                    {
+                        "type": "string",
+                        "description": "Suffix of the runner group of the team, selects its default runner group when unset",
+                        "name": "group",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "selected",
+                            "private",
+                            "all"
+                        ],
+                        "type": "string",
+                        "description": "Visibility of the runner group",
+                        "name": "visibility",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Allow public repositories to use the runner group",
+                        "name": "allowsPublicRepositories",
+                        "in": "query"
+                    },
                     {
                         "type": "array",
                         "items": {
                             "type": "string"
                         },
-                        "description": "Comma-seperated list of repository slugs",
-                        "name": "repos",
-                        "in": "query",
-                        "required": true
+                        "description": "Comma-seperated list of workflows the runner group is restricted to",
+                        "name": "workflows",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -244,21 +386,21 @@ var doc = `{
                 }
             }
         },
-        "/repos-remove": {
-            "patch": {
+        "/v1/groups-list": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Removes existing repositories to an existing GitHub Actions organization named with the team slug",
+                "description": "Lists every runner group named after the team, including the default runner group of the team and the runner groups told apart by their suffix",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Repos"
+                    "Groups"
                 ],
-                "summary": "Remove existing repositories from an existing GitHub Actions organization runner group",
+                "summary": "List every GitHub Action organization Runner Group of a team",
                 "parameters": [
                     {
                         "type": "string",
@@ -266,16 +408,64 @@ var doc = `{
                         "name": "team",
                         "in": "query",
                         "required": true
-                    },
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/apis.JSONResultSuccess"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "Code": {
+                                            "type": "integer"
+                                        },
+                                        "Response": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/apis.teamGroup"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/janitor-report": {
+            "get": {
+                "security": [
                     {
-                        "type": "array",
-                        "items": {
-                            "type": "string"
-                        },
-                        "description": "Comma-seperated list of repository slugs",
-                        "name": "repos",
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Lists the runners of the runner group named with the team slug that are offline, when they were first observed offline and whether the janitor considers them stale. No runners are removed.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Runners"
+                ],
+                "summary": "List the offline runners of a GitHub Actions organization runner group",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Canonical **slug** of the GitHub team",
+                        "name": "team",
                         "in": "query",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Suffix of the runner group of the team, selects its default runner group when unset",
+                        "name": "group",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -293,7 +483,7 @@ var doc = `{
                                             "type": "integer"
                                         },
                                         "Response": {
-                                            "type": "string"
+                                            "$ref": "#/definitions/apis.janitorReport"
                                         }
                                     }
                                 }
@@ -303,21 +493,21 @@ var doc = `{
                 }
             }
         },
-        "/repos-set": {
+        "/v1/labels-add": {
             "patch": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Replaces all existing repositories in an existing GitHub Actions organization named with the team slug with a new set of repositories",
+                "description": "Adds custom labels to a runner, identified by name or ID, that belongs to the runner group named with the team slug",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Repos"
+                    "Labels"
                 ],
-                "summary": "Replaces all existing repositories in an existing GitHub Actions organization runner group with a new set of repositories",
+                "summary": "Add custom labels to a runner in a GitHub Actions organization runner group",
                 "parameters": [
                     {
                         "type": "string",
@@ -326,13 +516,26 @@ var doc = `{
                         "in": "query",
                         "required": true
                     },
+                    {
+                        "type": "string",
+                        "description": "Suffix of the runner group of the team, selects its default runner group when unset",
+                        "name": "group",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Name or ID of the runner",
+                        "name": "runner",
+                        "in": "query",
+                        "required": true
+                    },
                     {
                         "type": "array",
                         "items": {
                             "type": "string"
                         },
-                        "description": "Comma-seperated list of repository slugs",
-                        "name": "repos",
+                        "description": "Comma-seperated list of custom labels",
+                        "name": "labels",
                         "in": "query",
                         "required": true
                     }
@@ -352,7 +555,10 @@ var doc = `{
                                             "type": "integer"
                                         },
                                         "Response": {
-                                            "type": "string"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/apis.runnerLabel"
+                                            }
                                         }
                                     }
                                 }
@@ -362,21 +568,21 @@ var doc = `{
                 }
             }
         },
-        "/token-register": {
+        "/v1/labels-list": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Creates a new GitHub Action organization runner removal token that can be used to configure GitHub Action runners at the organization level",
+                "description": "Lists the labels of a runner, identified by name or ID, that belongs to the runner group named with the team slug",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Tokens"
+                    "Labels"
                 ],
-                "summary": "Create a new GitHub Action organization runner registration token",
+                "summary": "List the labels of a runner in a GitHub Actions organization runner group",
                 "parameters": [
                     {
                         "type": "string",
@@ -384,6 +590,19 @@ var doc = `{
                         "name": "team",
                         "in": "query",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Suffix of the runner group of the team, selects its default runner group when unset",
+                        "name": "group",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Name or ID of the runner",
+                        "name": "runner",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -401,7 +620,10 @@ var doc = `{
                                             "type": "integer"
                                         },
                                         "Response": {
-                                            "$ref": "#/definitions/github.RegistrationToken"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/apis.runnerLabel"
+                                            }
                                         }
                                     }
                                 }
@@ -411,21 +633,21 @@ var doc = `{
                 }
             }
         },
-        "/token-remove": {
-            "get": {
+        "/v1/labels-remove": {
+            "patch": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Creates a new GitHub Action organization runner removal token that can be used remove a GitHub Action runners at the organization level",
+                "description": "Removes custom labels from a runner, identified by name or ID, that belongs to the runner group named with the team slug. Reserved labels cannot be removed.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Tokens"
+                    "Labels"
                 ],
-                "summary": "Create a new GitHub Action organization runner removal token",
+                "summary": "Remove custom labels from a runner in a GitHub Actions organization runner group",
                 "parameters": [
                     {
                         "type": "string",
@@ -433,6 +655,29 @@ var doc = `{
                         "name": "team",
                         "in": "query",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Suffix of the runner group of the team, selects its default runner group when unset",
+                        "name": "group",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Name or ID of the runner",
+                        "name": "runner",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "description": "Comma-seperated list of custom labels",
+                        "name": "labels",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -450,7 +695,10 @@ var doc = `{
                                             "type": "integer"
                                         },
                                         "Response": {
-                                            "$ref": "#/definitions/github.RegistrationToken"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/apis.runnerLabel"
+                                            }
                                         }
                                     }
                                 }
@@ -459,38 +707,1901 @@ var doc = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "apis.JSONResultSuccess": {
-            "type": "object",
-            "properties": {
-                "Code": {
-                    "type": "integer"
-                },
-                "Response": {}
-            }
         },
-        "apis.listResponse": {
-            "type": "object",
-            "properties": {
-                "repos": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
+        "/v1/labels-set": {
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
                     }
-                },
-                "runners": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
+                ],
+                "description": "Replaces all custom labels of a runner, identified by name or ID, that belongs to the runner group named with the team slug. Reserved labels are kept.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Labels"
+                ],
+                "summary": "Replace the custom labels of a runner in a GitHub Actions organization runner group",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Canonical **slug** of the GitHub team",
+                        "name": "team",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Suffix of the runner group of the team, selects its default runner group when unset",
+                        "name": "group",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Name or ID of the runner",
+                        "name": "runner",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "description": "Comma-seperated list of custom labels",
+                        "name": "labels",
+                        "in": "query",
+                        "required": true
                     }
-                }
-            }
-        },
-        "github.RegistrationToken": {
-            "type": "object",
-            "properties": {
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/apis.JSONResultSuccess"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "Code": {
+                                            "type": "integer"
+                                        },
+                                        "Response": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/apis.runnerLabel"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/repos-add": {
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Adds new repositories to an existing GitHub Actions organization named with the team slug. Every repository is attempted and its result reported, with a 207 status when any repository could not be added.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Repos"
+                ],
+                "summary": "Add new repositories to an existing GitHub Actions organization runner group",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Canonical **slug** of the GitHub team",
+                        "name": "team",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Suffix of the runner group of the team, selects its default runner group when unset",
+                        "name": "group",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Validate the request and return the change it would make without making it",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "description": "Comma-seperated list of repository slugs",
+                        "name": "repos",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/apis.JSONResultSuccess"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "Code": {
+                                            "type": "integer"
+                                        },
+                                        "Response": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/apis.repoResult"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "207": {
+                        "description": "Multi-Status",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/apis.JSONResultSuccess"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "Code": {
+                                            "type": "integer"
+                                        },
+                                        "Response": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/apis.repoResult"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/repos-remove": {
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Removes existing repositories to an existing GitHub Actions organization named with the team slug. Every repository is attempted and its result reported, with a 207 status when any repository could not be removed.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Repos"
+                ],
+                "summary": "Remove existing repositories from an existing GitHub Actions organization runner group",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Canonical **slug** of the GitHub team",
+                        "name": "team",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Suffix of the runner group of the team, selects its default runner group when unset",
+                        "name": "group",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Validate the request and return the change it would make without making it",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "description": "Comma-seperated list of repository slugs",
+                        "name": "repos",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/apis.JSONResultSuccess"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "Code": {
+                                            "type": "integer"
+                                        },
+                                        "Response": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/apis.repoResult"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "207": {
+                        "description": "Multi-Status",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/apis.JSONResultSuccess"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "Code": {
+                                            "type": "integer"
+                                        },
+                                        "Response": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/apis.repoResult"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/repos-set": {
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Replaces all existing repositories in an existing GitHub Actions organization named with the team slug with a new set of repositories",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Repos"
+                ],
+                "summary": "Replaces all existing repositories in an existing GitHub Actions organization runner group with a new set of repositories",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Canonical **slug** of the GitHub team",
+                        "name": "team",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Suffix of the runner group of the team, selects its default runner group when unset",
+                        "name": "group",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Validate the request and return the change it would make without making it",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "description": "Comma-seperated list of repository slugs",
+                        "name": "repos",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/apis.JSONResultSuccess"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "Code": {
+                                            "type": "integer"
+                                        },
+                                        "Response": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/runner-delete": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Removes a runner, identified by name or ID, from the organization after confirming it belongs to the runner group named with the team slug. Busy runners are not removed.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Runners"
+                ],
+                "summary": "Delete a runner from a GitHub Actions organization runner group",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Canonical **slug** of the GitHub team",
+                        "name": "team",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Suffix of the runner group of the team, selects its default runner group when unset",
+                        "name": "group",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Name or ID of the runner",
+                        "name": "runner",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/apis.JSONResultSuccess"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "Code": {
+                                            "type": "integer"
+                                        },
+                                        "Response": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/runner-list": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Lists the ID, operating system, status, busy flag and labels of every runner in the runner group named with the team slug",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Runners"
+                ],
+                "summary": "List the runners of a GitHub Actions organization runner group",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Canonical **slug** of the GitHub team",
+                        "name": "team",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Suffix of the runner group of the team, selects its default runner group when unset",
+                        "name": "group",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/apis.JSONResultSuccess"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "Code": {
+                                            "type": "integer"
+                                        },
+                                        "Response": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/apis.runnerDetails"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/runner-register": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Generates a just-in-time runner configuration bound to the runner group named with the team slug, so the runner can only register into the group of the team. Start the runner with ` + "`" + `./run.sh --jitconfig \u003cencodedJITConfig\u003e` + "`" + `.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Runners"
+                ],
+                "summary": "Register a GitHub Actions runner into the runner group of the team",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Canonical **slug** of the GitHub team",
+                        "name": "team",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Suffix of the runner group of the team, selects its default runner group when unset",
+                        "name": "group",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Name of the runner",
+                        "name": "name",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "description": "Comma-seperated list of additional runner labels",
+                        "name": "labels",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Working directory of the runner, defaults to _work",
+                        "name": "work",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/apis.JSONResultSuccess"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "Code": {
+                                            "type": "integer"
+                                        },
+                                        "Response": {
+                                            "$ref": "#/definitions/apis.JITRunnerConfig"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/token-register": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Creates a new GitHub Action organization runner removal token that can be used to configure GitHub Action runners at the organization level",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tokens"
+                ],
+                "summary": "Create a new GitHub Action organization runner registration token",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Canonical **slug** of the GitHub team",
+                        "name": "team",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/apis.JSONResultSuccess"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "Code": {
+                                            "type": "integer"
+                                        },
+                                        "Response": {
+                                            "$ref": "#/definitions/github.RegistrationToken"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/token-remove": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Creates a new GitHub Action organization runner removal token that can be used remove a GitHub Action runners at the organization level",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tokens"
+                ],
+                "summary": "Create a new GitHub Action organization runner removal token",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Canonical **slug** of the GitHub team",
+                        "name": "team",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/apis.JSONResultSuccess"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "Code": {
+                                            "type": "integer"
+                                        },
+                                        "Response": {
+                                            "$ref": "#/definitions/github.RegistrationToken"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/workflows-list": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Lists whether the runner group named with the team slug is restricted to selected workflows, and the workflows it is restricted to",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Workflows"
+                ],
+                "summary": "List the workflows a GitHub Actions organization runner group is restricted to",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Canonical **slug** of the GitHub team",
+                        "name": "team",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Suffix of the runner group of the team, selects its default runner group when unset",
+                        "name": "group",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/apis.JSONResultSuccess"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "Code": {
+                                            "type": "integer"
+                                        },
+                                        "Response": {
+                                            "$ref": "#/definitions/apis.workflowsResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/workflows-set": {
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Replaces the workflows the runner group named with the team slug is restricted to. An empty workflows parameter lifts the restriction, unless the configuration requires every runner group to be restricted.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Workflows"
+                ],
+                "summary": "Restrict a GitHub Actions organization runner group to selected workflows",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Canonical **slug** of the GitHub team",
+                        "name": "team",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Suffix of the runner group of the team, selects its default runner group when unset",
+                        "name": "group",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "description": "Comma-seperated list of workflows, formatted as \u003cowner\u003e/\u003crepo\u003e/\u003cpath\u003e@\u003cref\u003e",
+                        "name": "workflows",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/apis.JSONResultSuccess"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "Code": {
+                                            "type": "integer"
+                                        },
+                                        "Response": {
+                                            "$ref": "#/definitions/apis.workflowsResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/teams/{team}/runner-group": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "List all repositories and runners assigned to the runner group of the team, as the v1 group-list API",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Groups"
+                ],
+                "summary": "List all resources associated with a GitHub Action organization Runner Group",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Canonical **slug** of the GitHub team",
+                        "name": "team",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Suffix of the runner group of the team, selects its default runner group when unset",
+                        "name": "group",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/apis.JSONResultSuccess"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "Code": {
+                                            "type": "integer"
+                                        },
+                                        "Response": {
+                                            "$ref": "#/definitions/apis.listResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Creates the runner group of the team with the settings of the body, as the v1 group-create API",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Groups"
+                ],
+                "summary": "Create a new GitHub Action organization Runner Group",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Canonical **slug** of the GitHub team",
+                        "name": "team",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Suffix of the runner group of the team, selects its default runner group when unset",
+                        "name": "group",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Validate the request and return the change it would make without making it",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Settings of the runner group",
+                        "name": "body",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/apis.v2GroupRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/apis.JSONResultSuccess"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "Code": {
+                                            "type": "integer"
+                                        },
+                                        "Response": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Deletes the runner group of the team, as the v1 group-delete API",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Groups"
+                ],
+                "summary": "Deletes an existing GitHub Action organization Runner Group",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Canonical **slug** of the GitHub team",
+                        "name": "team",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Suffix of the runner group of the team, selects its default runner group when unset",
+                        "name": "group",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Validate the request and return the change it would make without making it",
+                        "name": "dry_run",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/apis.JSONResultSuccess"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "Code": {
+                                            "type": "integer"
+                                        },
+                                        "Response": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Updates the settings of the body on the runner group of the team, leaving the others unchanged, as the v1 group-update API",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Groups"
+                ],
+                "summary": "Update the settings of an existing GitHub Action organization Runner Group",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Canonical **slug** of the GitHub team",
+                        "name": "team",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Suffix of the runner group of the team, selects its default runner group when unset",
+                        "name": "group",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Validate the request and return the change it would make without making it",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Settings of the runner group",
+                        "name": "body",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/apis.v2GroupRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/apis.JSONResultSuccess"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "Code": {
+                                            "type": "integer"
+                                        },
+                                        "Response": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/teams/{team}/runner-group/repositories": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Replaces the repositories of the runner group of the team with the repositories of the body, as the v1 repos-set API",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Repos"
+                ],
+                "summary": "Replaces all existing repositories in an existing GitHub Actions organization runner group with a new set of repositories",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Canonical **slug** of the GitHub team",
+                        "name": "team",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Suffix of the runner group of the team, selects its default runner group when unset",
+                        "name": "group",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Validate the request and return the change it would make without making it",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Repositories of the runner group",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/apis.v2RepositoriesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/apis.JSONResultSuccess"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "Code": {
+                                            "type": "integer"
+                                        },
+                                        "Response": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Adds the repositories of the body to the runner group of the team, as the v1 repos-add API",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Repos"
+                ],
+                "summary": "Add new repositories to an existing GitHub Actions organization runner group",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Canonical **slug** of the GitHub team",
+                        "name": "team",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Suffix of the runner group of the team, selects its default runner group when unset",
+                        "name": "group",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Validate the request and return the change it would make without making it",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Repositories to add",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/apis.v2RepositoriesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/apis.JSONResultSuccess"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "Code": {
+                                            "type": "integer"
+                                        },
+                                        "Response": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/apis.repoResult"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "207": {
+                        "description": "Multi-Status",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/apis.JSONResultSuccess"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "Code": {
+                                            "type": "integer"
+                                        },
+                                        "Response": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/apis.repoResult"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/teams/{team}/runner-group/repositories/{repo}": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Adds the repository of the path to the runner group of the team, as the v1 repos-add API",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Repos"
+                ],
+                "summary": "Add a repository to an existing GitHub Actions organization runner group",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Canonical **slug** of the GitHub team",
+                        "name": "team",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Slug of the repository",
+                        "name": "repo",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Suffix of the runner group of the team, selects its default runner group when unset",
+                        "name": "group",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Validate the request and return the change it would make without making it",
+                        "name": "dry_run",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/apis.JSONResultSuccess"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "Code": {
+                                            "type": "integer"
+                                        },
+                                        "Response": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/apis.repoResult"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "207": {
+                        "description": "Multi-Status",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/apis.JSONResultSuccess"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "Code": {
+                                            "type": "integer"
+                                        },
+                                        "Response": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/apis.repoResult"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Removes the repository of the path from the runner group of the team, as the v1 repos-remove API",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Repos"
+                ],
+                "summary": "Remove a repository from an existing GitHub Actions organization runner group",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Canonical **slug** of the GitHub team",
+                        "name": "team",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Slug of the repository",
+                        "name": "repo",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Suffix of the runner group of the team, selects its default runner group when unset",
+                        "name": "group",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Validate the request and return the change it would make without making it",
+                        "name": "dry_run",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/apis.JSONResultSuccess"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "Code": {
+                                            "type": "integer"
+                                        },
+                                        "Response": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/apis.repoResult"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "207": {
+                        "description": "Multi-Status",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/apis.JSONResultSuccess"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "Code": {
+                                            "type": "integer"
+                                        },
+                                        "Response": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/apis.repoResult"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/teams/{team}/runner-group/runners": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Lists the runners of the runner group of the team, as the v1 runner-list API",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Runners"
+                ],
+                "summary": "List the runners of a GitHub Actions organization runner group",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Canonical **slug** of the GitHub team",
+                        "name": "team",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Suffix of the runner group of the team, selects its default runner group when unset",
+                        "name": "group",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/apis.JSONResultSuccess"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "Code": {
+                                            "type": "integer"
+                                        },
+                                        "Response": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/apis.runnerDetails"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Generates a just-in-time runner configuration bound to the runner group of the team, as the v1 runner-register API",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Runners"
+                ],
+                "summary": "Register a GitHub Actions runner into the runner group of the team",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Canonical **slug** of the GitHub team",
+                        "name": "team",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Suffix of the runner group of the team, selects its default runner group when unset",
+                        "name": "group",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Name, additional labels and working directory of the runner",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/apis.v2RunnerRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/apis.JSONResultSuccess"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "Code": {
+                                            "type": "integer"
+                                        },
+                                        "Response": {
+                                            "$ref": "#/definitions/apis.JITRunnerConfig"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/teams/{team}/runner-group/runners/{runner}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Removes the runner of the path after confirming it belongs to the runner group of the team, as the v1 runner-delete API",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Runners"
+                ],
+                "summary": "Delete a runner from a GitHub Actions organization runner group",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Canonical **slug** of the GitHub team",
+                        "name": "team",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Name or ID of the runner",
+                        "name": "runner",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Suffix of the runner group of the team, selects its default runner group when unset",
+                        "name": "group",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/apis.JSONResultSuccess"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "Code": {
+                                            "type": "integer"
+                                        },
+                                        "Response": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/teams/{team}/runner-groups": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Lists the runner groups of the team, as the v1 groups-list API",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Groups"
+                ],
+                "summary": "List every GitHub Action organization Runner Group of a team",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Canonical **slug** of the GitHub team",
+                        "name": "team",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/apis.JSONResultSuccess"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "Code": {
+                                            "type": "integer"
+                                        },
+                                        "Response": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/apis.teamGroup"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "apis.AuditEvent": {
+            "type": "object",
+            "properties": {
+                "ancestry": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "code": {
+                    "type": "integer"
+                },
+                "group": {
+                    "type": "string"
+                },
+                "operation": {
+                    "type": "string"
+                },
+                "org": {
+                    "type": "string"
+                },
+                "reposAfter": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "reposBefore": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "requestID": {
+                    "type": "string"
+                },
+                "runner": {
+                    "type": "string"
+                },
+                "team": {
+                    "type": "string"
+                },
+                "time": {
+                    "type": "string"
+                },
+                "user": {
+                    "type": "string"
+                }
+            }
+        },
+        "apis.JITRunnerConfig": {
+            "type": "object",
+            "properties": {
+                "encoded_jit_config": {
+                    "type": "string"
+                },
+                "runner": {
+                    "$ref": "#/definitions/github.Runner"
+                }
+            }
+        },
+        "apis.JSONResultSuccess": {
+            "type": "object",
+            "properties": {
+                "Code": {
+                    "type": "integer"
+                },
+                "Response": {}
+            }
+        },
+        "apis.janitorReport": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "type": "boolean"
+                },
+                "offlineThreshold": {
+                    "type": "string"
+                },
+                "runners": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/apis.offlineRunner"
+                    }
+                },
+                "team": {
+                    "type": "string"
+                }
+            }
+        },
+        "apis.listResponse": {
+            "type": "object",
+            "properties": {
+                "repos": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "runners": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "apis.offlineRunner": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "offlineSince": {
+                    "type": "string"
+                },
+                "stale": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "apis.repoResult": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "errorCode": {
+                    "type": "string"
+                },
+                "repo": {
+                    "type": "string"
+                },
+                "retryable": {
+                    "type": "boolean"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "apis.runnerDetails": {
+            "type": "object",
+            "properties": {
+                "busy": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "labels": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/apis.runnerLabel"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "os": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "apis.runnerLabel": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "apis.teamGroup": {
+            "type": "object",
+            "properties": {
+                "allowsPublicRepositories": {
+                    "type": "boolean"
+                },
+                "group": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "visibility": {
+                    "type": "string"
+                }
+            }
+        },
+        "apis.v2GroupRequest": {
+            "type": "object",
+            "required": [
+                "workflows"
+            ],
+            "properties": {
+                "allowsPublicRepositories": {
+                    "type": "boolean"
+                },
+                "visibility": {
+                    "type": "string",
+                    "enum": [
+                        "selected",
+                        "private",
+                        "all"
+                    ]
+                },
+                "workflows": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "apis.v2RepositoriesRequest": {
+            "type": "object",
+            "required": [
+                "repositories"
+            ],
+            "properties": {
+                "repositories": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "apis.v2RunnerRequest": {
+            "type": "object",
+            "required": [
+                "labels",
+                "name"
+            ],
+            "properties": {
+                "labels": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "work": {
+                    "type": "string"
+                }
+            }
+        },
+        "apis.workflowsResponse": {
+            "type": "object",
+            "properties": {
+                "restricted": {
+                    "type": "boolean"
+                },
+                "workflows": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "github.RegistrationToken": {
+            "type": "object",
+            "properties": {
                 "expires_at": {
                     "$ref": "#/definitions/github.Timestamp"
                 },
@@ -499,6 +2610,46 @@ var doc = `{
                 }
             }
         },
+        "github.Runner": {
+            "type": "object",
+            "properties": {
+                "busy": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "labels": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github.RunnerLabels"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "os": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "github.RunnerLabels": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
         "github.Timestamp": {
             "type": "object",
             "properties": {
@@ -530,7 +2681,7 @@ type swaggerInfo struct {
 var SwaggerInfo = swaggerInfo{
 	Version:     "0.1.0",
 	Host:        "localhost",
-	BasePath:    "/api/v1",
+	BasePath:    "/api",
 	Schemes:     []string{},
 	Title:       "Action Runner Manager API",
 	Description: "API for managing GitHub organization Runner Groups",
@@ -58,6 +58,9 @@ func initializeManager(t *testing.T) (*apis.Manager, []byte) {
 			Port:    54321,
 		},
 		Org: os.Getenv("MANAGER_ORG"),
+		GitHub: apis.GitHub{
+			BaseURL: os.Getenv("MANAGER_API_URL"),
+		},
 		Logging: apis.Logging{
 			Ephemeral: true,
 		},
@@ -65,12 +68,12 @@ func initializeManager(t *testing.T) (*apis.Manager, []byte) {
 	logger, _ := test.NewNullLogger()
 	itr, err := ghinstallation.New(http.DefaultTransport, config.AppID, config.InstallationID, privateKey)
 	require.NoError(t, err)
+	itr.BaseURL = config.GitHub.APIURL()
 
 	lmt := tollbooth.NewLimiter(5, &limiter.ExpirableOptions{DefaultExpirationTTL: time.Hour})
 	lmt.SetHeader("Authorization", []string{})
 	lmt.SetHeaderEntryExpirationTTL(time.Hour)
-	lmt.SetMessage(`{"code":429,"response":"You have reached maximum request limit. Please try again in a few seconds."}`)
-	lmt.SetMessageContentType("application/json")
+	lmt.SetMessage("You have reached maximum request limit. Please try again in a few seconds.")
 
 	createClient := func(token, uuid string) (*apis.MaintainershipClient, *github.User, error) {
 		ctx := context.Background()
@@ -78,14 +81,16 @@ func initializeManager(t *testing.T) (*apis.Manager, []byte) {
 			&oauth2.Token{AccessToken: token},
 		)
 		tc := oauth2.NewClient(ctx, ts)
-		client := github.NewClient(tc)
+		client, err := config.GitHub.NewClient(tc)
+		require.NoError(t, err)
 
 		user, _, err := client.Users.Get(context.Background(), "")
 		require.NoError(t, err)
 		lmt.SetBasicAuthUsers(append(lmt.GetBasicAuthUsers(), user.GetLogin()))
 		return &apis.MaintainershipClient{
-			TeamsClient: client.Teams,
-			UsersClient: client.Users,
+			OrganizationsClient: client.Organizations,
+			TeamsClient:         client.Teams,
+			UsersClient:         client.Users,
 		}, user, nil
 	}
 
@@ -97,10 +102,13 @@ func initializeManager(t *testing.T) (*apis.Manager, []byte) {
 	}))
 	router.Use(gin.Logger())
 
-	client := github.NewClient(&http.Client{Transport: itr})
+	client, err := config.GitHub.NewClient(&http.Client{Transport: itr})
+	require.NoError(t, err)
 	manager := &apis.Manager{
 		ActionsClient:      client.Actions,
 		RepositoriesClient: client.Repositories,
+		RestClient:         client,
+		TokenSource:        itr,
 		TeamsClient:        client.Teams,
 		Router:             router,
 		Limit:              lmt,
@@ -119,7 +127,9 @@ func initializeManager(t *testing.T) (*apis.Manager, []byte) {
 func createGitHubClient(t *testing.T, config *apis.Config, privateKey []byte) *github.Client {
 	itr, err := ghinstallation.New(http.DefaultTransport, config.AppID, config.InstallationID, privateKey)
 	require.NoError(t, err)
-	client := github.NewClient(&http.Client{Transport: itr})
+	itr.BaseURL = config.GitHub.APIURL()
+	client, err := config.GitHub.NewClient(&http.Client{Transport: itr})
+	require.NoError(t, err)
 	return client
 }
 
@@ -209,8 +219,10 @@ func TestE2E(t *testing.T) {
 	require.Equal(t, expected, response)
 
 	expected = &Response{
-		Code:     http.StatusOK,
-		Response: "Successfully added repositories to runner group",
+		Code: http.StatusOK,
+		Response: []interface{}{
+			map[string]interface{}{"repo": slug, "status": apis.RepoStatusAdded},
+		},
 	}
 	url = fmt.Sprintf("http://%s/api/v1/repos-add?team=%s&repos=%s", manager.Server.Addr, slug, slug)
 	response = do(t, http.MethodPatch, url)
@@ -228,8 +240,10 @@ func TestE2E(t *testing.T) {
 	require.Equal(t, expected, response)
 
 	expected = &Response{
-		Code:     http.StatusOK,
-		Response: "Successfully removed repositories from runner group",
+		Code: http.StatusOK,
+		Response: []interface{}{
+			map[string]interface{}{"repo": slug, "status": apis.RepoStatusRemoved},
+		},
 	}
 	url = fmt.Sprintf("http://%s/api/v1/repos-remove?team=%s&repos=%s", manager.Server.Addr, slug, slug)
 	response = do(t, http.MethodPatch, url)
@@ -0,0 +1,235 @@
+/**
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package apis
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gin-contrib/requestid"
+	"github.com/gin-gonic/gin"
+	"github.com/google/go-github/v41/github"
+)
+
+const (
+	operationKey = "operation"
+	runnerKey    = "runner"
+)
+
+// repositoryOperations change the repository access of a runner group, so the repositories assigned to the group are
+// recorded before and after the operation
+var repositoryOperations = map[string]bool{
+	OperationGroupCreate: true,
+	OperationGroupDelete: true,
+	OperationReposAdd:    true,
+	OperationReposRemove: true,
+	OperationReposSet:    true,
+}
+
+// AuditSink persists audit events and queries them back
+type AuditSink interface {
+	Write(event *AuditEvent) error
+	Query(filter *AuditFilter) ([]*AuditEvent, error)
+	Close() error
+}
+
+// AuditEvent records a single mutating API call
+type AuditEvent struct {
+	Time        time.Time `json:"time"`
+	RequestID   string    `json:"requestID"`
+	Org         string    `json:"org,omitempty"`
+	User        string    `json:"user"`
+	Team        string    `json:"team"`
+	Group       string    `json:"group,omitempty"`
+	Ancestry    []string  `json:"ancestry,omitempty"`
+	Operation   string    `json:"operation"`
+	Runner      string    `json:"runner,omitempty"`
+	ReposBefore []string  `json:"reposBefore,omitempty"`
+	ReposAfter  []string  `json:"reposAfter,omitempty"`
+	Code        int       `json:"code"`
+}
+
+// AuditFilter selects the audit events of a team in the half-open time range [Since, Until). A zero Since or Until
+// leaves that end of the range unbounded. Events without an organization were recorded before organizations were
+// recorded and belong to DefaultOrg.
+type AuditFilter struct {
+	Org        string
+	DefaultOrg string
+	Team       string
+	Since      time.Time
+	Until      time.Time
+}
+
+// Matches reports whether the event is selected by the filter
+func (f *AuditFilter) Matches(event *AuditEvent) bool {
+	org := event.Org
+	if org == "" {
+		org = f.DefaultOrg
+	}
+	if f.Org != "" && !strings.EqualFold(org, f.Org) {
+		return false
+	}
+	if f.Team != "" && event.Team != f.Team {
+		return false
+	}
+	if !f.Since.IsZero() && event.Time.Before(f.Since) {
+		return false
+	}
+	if !f.Until.IsZero() && !event.Time.Before(f.Until) {
+		return false
+	}
+	return true
+}
+
+// NewAuditSink creates the audit sink selected in the configuration, or nil if auditing is disabled
+func NewAuditSink(config Audit) (AuditSink, error) {
+	switch config.Sink {
+	case "":
+		return nil, nil
+	case "file":
+		return NewFileAuditSink(config.Path)
+	case "bolt":
+		return NewBoltAuditSink(config.Path)
+	default:
+		return nil, fmt.Errorf("unknown audit sink %s", config.Sink)
+	}
+}
+
+// Audit records an audit event for the operation stored on the request context once the handler has completed, along
+// with the runner the handler resolved, if any. Dry runs change nothing and are not recorded. It must be registered after
+// RequirePermission.
+func (m *Manager) Audit(c *gin.Context) {
+	if m.AuditLog == nil || isDryRun(c) {
+		return
+	}
+	uuid := requestid.Get(c)
+	team := c.GetString(teamKey)
+	suffix := c.Query("group")
+	operation := c.GetString(operationKey)
+
+	event := &AuditEvent{
+		RequestID: uuid,
+		Org:       m.Config.Org,
+		User:      c.GetString(userKey),
+		Team:      team,
+		Group:     suffix,
+		Ancestry:  c.GetStringSlice(ancestryKey),
+		Operation: operation,
+	}
+	if repositoryOperations[operation] {
+		event.ReposBefore = m.snapshotRepos(team, suffix, uuid)
+	}
+
+	c.Next()
+
+	if repositoryOperations[operation] {
+		event.ReposAfter = m.snapshotRepos(team, suffix, uuid)
+	}
+	event.Runner = c.GetString(runnerKey)
+	event.Code = c.Writer.Status()
+	event.Time = time.Now().UTC()
+
+	m.Logger.WithField("uuid", uuid).WithField("team", team).Info("Writing audit event")
+	if err := m.AuditLog.Write(event); err != nil {
+		m.Logger.WithField("uuid", uuid).WithField("team", team).Errorf("Unable to write audit event: %v", err)
+		return
+	}
+	m.Logger.WithField("uuid", uuid).WithField("team", team).Debug("Wrote audit event")
+}
+
+// snapshotRepos lists the repositories assigned to the runner group of the team with the suffix, or nil if the group
+// does not exist
+func (m *Manager) snapshotRepos(team, suffix, uuid string) []string {
+	groupID, err := m.retrieveGroupID(team, suffix, uuid)
+	if err != nil {
+		return nil
+	}
+
+	var names []string
+	opts := &github.ListOptions{PerPage: 100}
+	for {
+		runnerGroupRepos, resp, err := m.ActionsClient.ListRepositoryAccessRunnerGroup(context.Background(), m.Config.Org, *groupID, opts)
+		if err != nil {
+			m.Logger.WithField("uuid", uuid).WithField("team", team).Errorf("Unable to list repositories for audit event: %v", err)
+			return nil
+		}
+		for _, repo := range runnerGroupRepos.Repositories {
+			names = append(names, repo.GetName())
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	sort.Strings(names)
+	if names == nil {
+		names = []string{}
+	}
+	return names
+}
+
+// DoAuditList   List the audit events of a team
+// @Summary      List the audit events of a team
+// @Description  Lists the audit events recorded for mutating API calls made on the team, optionally limited to a time range
+// @Tags         Audit
+// @Produce      json
+// @Param        team   query     string  true   "Canonical **slug** of the GitHub team"
+// @Param        since  query     string  false  "Only list events recorded at or after this RFC 3339 timestamp"
+// @Param        until  query     string  false  "Only list events recorded before this RFC 3339 timestamp"
+// @Success      200    {object}  JSONResultSuccess{Code=int,Response=[]AuditEvent}
+// @Router       /v1/audit [get]
+// @Security     ApiKeyAuth
+func (m *Manager) DoAuditList(c *gin.Context) {
+	uuid := requestid.Get(c)
+	team := c.GetString(teamKey)
+
+	if m.AuditLog == nil {
+		writeError(c, http.StatusNotFound, ErrorCodeNotFound, "Audit logging is not enabled")
+		return
+	}
+
+	m.Logger.WithField("uuid", uuid).WithField("team", team).Info("Retrieving time range parameters")
+	filter := &AuditFilter{Org: m.Config.Org, DefaultOrg: m.defaultOrganization(), Team: team}
+	params := []struct {
+		name  string
+		value *time.Time
+	}{
+		{name: "since", value: &filter.Since},
+		{name: "until", value: &filter.Until},
+	}
+	for _, param := range params {
+		value := c.Query(param.name)
+		if value == "" {
+			continue
+		}
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			writeError(c, http.StatusBadRequest, ErrorCodeBadRequest, fmt.Sprintf("Invalid parameter %s, expected an RFC 3339 timestamp: %v", param.name, err))
+			return
+		}
+		*param.value = parsed
+	}
+	m.Logger.WithField("uuid", uuid).WithField("team", team).Debug("Retrieved time range parameters")
+
+	m.Logger.WithField("uuid", uuid).WithField("team", team).Info("Querying audit events")
+	events, err := m.AuditLog.Query(filter)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, ErrorCodeInternal, fmt.Sprintf("Unable to query audit events: %v", err))
+		return
+	}
+	if events == nil {
+		events = []*AuditEvent{}
+	}
+	m.Logger.WithField("uuid", uuid).WithField("team", team).Debug("Queried audit events")
+
+	c.JSON(http.StatusOK, &JSONResultSuccess{
+		Code:     http.StatusOK,
+		Response: events,
+	})
+}
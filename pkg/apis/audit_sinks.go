@@ -0,0 +1,173 @@
+/**
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package apis
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+var auditBucket = []byte("events")
+
+// FileAuditSink appends audit events to a file as JSON lines
+type FileAuditSink struct {
+	mutex sync.Mutex
+	file  *os.File
+}
+
+// NewFileAuditSink opens, or creates, the append-only audit file at path
+func NewFileAuditSink(path string) (*FileAuditSink, error) {
+	if path == "" {
+		return nil, fmt.Errorf("file audit sink requires a path")
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return nil, fmt.Errorf("unable to create audit directory: %w", err)
+	}
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_RDWR, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open audit file: %w", err)
+	}
+	return &FileAuditSink{file: file}, nil
+}
+
+// Write appends the event to the audit file
+func (s *FileAuditSink) Write(event *AuditEvent) error {
+	bytes, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("unable to marshal audit event: %w", err)
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if _, err := s.file.Write(append(bytes, '\n')); err != nil {
+		return fmt.Errorf("unable to write audit event: %w", err)
+	}
+	return nil
+}
+
+// Query scans the audit file for the events selected by the filter
+func (s *FileAuditSink) Query(filter *AuditFilter) ([]*AuditEvent, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	file, err := os.Open(s.file.Name())
+	if err != nil {
+		return nil, fmt.Errorf("unable to open audit file: %w", err)
+	}
+	defer file.Close()
+
+	var events []*AuditEvent
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		event := &AuditEvent{}
+		if err := json.Unmarshal(scanner.Bytes(), event); err != nil {
+			return nil, fmt.Errorf("unable to parse audit event: %w", err)
+		}
+		if filter.Matches(event) {
+			events = append(events, event)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("unable to read audit file: %w", err)
+	}
+	return events, nil
+}
+
+// Close closes the audit file
+func (s *FileAuditSink) Close() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.file.Close()
+}
+
+// BoltAuditSink stores audit events in an embedded bbolt database keyed on the time they were recorded
+type BoltAuditSink struct {
+	db *bolt.DB
+}
+
+// NewBoltAuditSink opens, or creates, the bbolt audit database at path
+func NewBoltAuditSink(path string) (*BoltAuditSink, error) {
+	if path == "" {
+		return nil, fmt.Errorf("bolt audit sink requires a path")
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return nil, fmt.Errorf("unable to create audit directory: %w", err)
+	}
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("unable to open audit database: %w", err)
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(auditBucket)
+		return err
+	})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("unable to create audit bucket: %w", err)
+	}
+	return &BoltAuditSink{db: db}, nil
+}
+
+// Write stores the event under a key ordered by the event time
+func (s *BoltAuditSink) Write(event *AuditEvent) error {
+	bytes, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("unable to marshal audit event: %w", err)
+	}
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(auditBucket)
+		sequence, err := bucket.NextSequence()
+		if err != nil {
+			return err
+		}
+		key := make([]byte, 16)
+		binary.BigEndian.PutUint64(key, uint64(event.Time.UnixNano()))
+		binary.BigEndian.PutUint64(key[8:], sequence)
+		return bucket.Put(key, bytes)
+	})
+}
+
+// Query seeks to the start of the time range and returns the events selected by the filter
+func (s *BoltAuditSink) Query(filter *AuditFilter) ([]*AuditEvent, error) {
+	var events []*AuditEvent
+	err := s.db.View(func(tx *bolt.Tx) error {
+		seek := make([]byte, 16)
+		if !filter.Since.IsZero() {
+			binary.BigEndian.PutUint64(seek, uint64(filter.Since.UnixNano()))
+		}
+		cursor := tx.Bucket(auditBucket).Cursor()
+		for key, value := cursor.Seek(seek); key != nil; key, value = cursor.Next() {
+			event := &AuditEvent{}
+			if err := json.Unmarshal(value, event); err != nil {
+				return fmt.Errorf("unable to parse audit event: %w", err)
+			}
+			if !filter.Until.IsZero() && !event.Time.Before(filter.Until) {
+				break
+			}
+			if filter.Matches(event) {
+				events = append(events, event)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// Close closes the audit database
+func (s *BoltAuditSink) Close() error {
+	return s.db.Close()
+}
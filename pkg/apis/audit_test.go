@@ -0,0 +1,139 @@
+/**
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package apis
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/didip/tollbooth/v6"
+	"github.com/gin-gonic/gin"
+	"github.com/google/go-github/v41/github"
+	"github.com/lindluni/actions-runner-manager/pkg/apis/mocks"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditSinks(t *testing.T) {
+	t.Parallel()
+
+	for _, sinkType := range []string{"file", "bolt"} {
+		sink, err := NewAuditSink(Audit{Sink: sinkType, Path: filepath.Join(t.TempDir(), "audit", "events")})
+		require.NoError(t, err, sinkType)
+
+		start := time.Date(2021, 12, 1, 0, 0, 0, 0, time.UTC)
+		events := []*AuditEvent{
+			{Time: start, RequestID: "1", Team: "fake-team", Operation: OperationGroupCreate, ReposAfter: []string{}},
+			{Time: start.Add(time.Hour), RequestID: "2", Team: "other-team", Operation: OperationTokenRegister},
+			{Time: start.Add(2 * time.Hour), RequestID: "3", Team: "fake-team", Operation: OperationReposAdd, ReposBefore: []string{}, ReposAfter: []string{"fake-repo"}},
+			{Time: start.Add(3 * time.Hour), RequestID: "4", Team: "fake-team", Operation: OperationGroupDelete},
+		}
+		for _, event := range events {
+			require.NoError(t, sink.Write(event), sinkType)
+		}
+
+		queried, err := sink.Query(&AuditFilter{Team: "fake-team"})
+		require.NoError(t, err, sinkType)
+		require.Len(t, queried, 3, sinkType)
+		require.Equal(t, "1", queried[0].RequestID, sinkType)
+		require.Equal(t, []string{"fake-repo"}, queried[1].ReposAfter, sinkType)
+
+		queried, err = sink.Query(&AuditFilter{Team: "fake-team", Since: start.Add(time.Hour), Until: start.Add(3 * time.Hour)})
+		require.NoError(t, err, sinkType)
+		require.Len(t, queried, 1, sinkType)
+		require.Equal(t, "3", queried[0].RequestID, sinkType)
+
+		require.NoError(t, sink.Close(), sinkType)
+	}
+
+	_, err := NewAuditSink(Audit{Sink: "syslog"})
+	require.EqualError(t, err, "unknown audit sink syslog")
+	_, err = NewAuditSink(Audit{Sink: "file"})
+	require.EqualError(t, err, "file audit sink requires a path")
+	sink, err := NewAuditSink(Audit{})
+	require.NoError(t, err)
+	require.Nil(t, sink)
+}
+
+func TestAudit(t *testing.T) {
+	t.Parallel()
+
+	sink, err := NewFileAuditSink(filepath.Join(t.TempDir(), "audit.jsonl"))
+	require.NoError(t, err)
+	defer sink.Close()
+
+	actionsClient := &mocks.ActionsClient{}
+	actionsClient.ListOrganizationRunnerGroupsReturns(&github.RunnerGroups{
+		RunnerGroups: []*github.RunnerGroup{{ID: github.Int64(1), Name: github.String("fake-team")}},
+	}, &github.Response{}, nil)
+	actionsClient.ListRepositoryAccessRunnerGroupReturnsOnCall(0, &github.ListRepositories{}, &github.Response{}, nil)
+	actionsClient.ListRepositoryAccessRunnerGroupReturnsOnCall(1, &github.ListRepositories{}, &github.Response{}, nil)
+	actionsClient.ListRepositoryAccessRunnerGroupReturnsOnCall(2, &github.ListRepositories{
+		Repositories: []*github.Repository{{Name: github.String("fake-repo")}},
+	}, &github.Response{}, nil)
+	teamsClient := &mocks.TeamsClient{}
+	teamsClient.ListTeamReposBySlugReturns([]*github.Repository{{ID: github.Int64(1), Name: github.String("fake-repo")}}, &github.Response{}, nil)
+	teamsClient.GetTeamMembershipBySlugReturns(&github.Membership{Role: github.String("maintainer")}, nil, nil)
+	logger, _ := test.NewNullLogger()
+	manager := &Manager{
+		ActionsClient: actionsClient,
+		AuditLog:      sink,
+		Config:        &Config{Org: "fake-org"},
+		CreateMaintainershipClient: func(string, string) (*MaintainershipClient, *github.User, error) {
+			return &MaintainershipClient{
+				TeamsClient: teamsClient,
+			}, &github.User{Login: github.String("fake-user")}, nil
+		},
+		Limit:       tollbooth.NewLimiter(10, nil),
+		Logger:      logger,
+		Router:      gin.New(),
+		TeamsClient: teamsClient,
+	}
+	manager.SetRoutes()
+
+	writer := httptest.NewRecorder()
+	request, err := http.NewRequest(http.MethodPatch, "/api/v1/repos-add?team=fake-team&repos=fake-repo", nil)
+	require.NoError(t, err)
+	request.Header.Set("Authorization", "test-token")
+	manager.Router.ServeHTTP(writer, request)
+	require.Equal(t, http.StatusOK, writer.Code)
+
+	writer = httptest.NewRecorder()
+	request, err = http.NewRequest(http.MethodGet, "/api/v1/audit?team=fake-team", nil)
+	require.NoError(t, err)
+	request.Header.Set("Authorization", "test-token")
+	manager.Router.ServeHTTP(writer, request)
+
+	result := writer.Result()
+	body, err := ioutil.ReadAll(result.Body)
+	defer result.Body.Close()
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, result.StatusCode)
+
+	response := &struct {
+		Code     int           `json:"code"`
+		Response []*AuditEvent `json:"response"`
+	}{}
+	require.NoError(t, json.Unmarshal(body, response))
+	require.Len(t, response.Response, 1)
+	event := response.Response[0]
+	require.Equal(t, "fake-user", event.User)
+	require.Equal(t, "fake-team", event.Team)
+	require.Equal(t, OperationReposAdd, event.Operation)
+	require.Equal(t, []string{"fake-repo"}, event.ReposAfter)
+	require.Equal(t, http.StatusOK, event.Code)
+
+	writer = httptest.NewRecorder()
+	request, err = http.NewRequest(http.MethodGet, "/api/v1/audit?team=fake-team&since=yesterday", nil)
+	require.NoError(t, err)
+	request.Header.Set("Authorization", "test-token")
+	manager.Router.ServeHTTP(writer, request)
+	require.Equal(t, http.StatusBadRequest, writer.Code)
+}
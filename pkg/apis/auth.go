@@ -0,0 +1,122 @@
+/**
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package apis
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-contrib/requestid"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	ancestryKey = "ancestry"
+	rolesKey    = "roles"
+	teamKey     = "team"
+	tokenKey    = "token"
+	userKey     = "user"
+)
+
+// AuthorizationHandlers returns the middleware chain that resolves the team and the caller's token before a team
+// scoped handler is invoked. Each team scoped route must additionally be guarded by RequirePermission.
+func (m *Manager) AuthorizationHandlers() []gin.HandlerFunc {
+	return []gin.HandlerFunc{
+		m.RequireTeam,
+		m.RequireToken,
+	}
+}
+
+// RequireTeam retrieves the team parameter and stores it on the request context
+func (m *Manager) RequireTeam(c *gin.Context) {
+	uuid := requestid.Get(c)
+
+	m.Logger.WithField("uuid", uuid).Info("Retrieving team parameter")
+	team := c.Query("team")
+	if team == "" {
+		writeError(c, http.StatusBadRequest, ErrorCodeBadRequest, "Missing required parameter: team")
+		c.Abort()
+		return
+	}
+	c.Set(teamKey, team)
+	m.Logger.WithField("uuid", uuid).WithField("team", team).Debug("Retrieved team parameter")
+}
+
+// RequireTeamPath retrieves the team path parameter of the v2 API and stores it on the request context
+func (m *Manager) RequireTeamPath(c *gin.Context) {
+	uuid := requestid.Get(c)
+	team := c.Param("team")
+	c.Set(teamKey, team)
+	m.Logger.WithField("uuid", uuid).WithField("team", team).Debug("Retrieved team path parameter")
+}
+
+// RequireToken retrieves the Authorization header and stores it on the request context
+func (m *Manager) RequireToken(c *gin.Context) {
+	uuid := requestid.Get(c)
+	team := c.GetString(teamKey)
+
+	m.Logger.WithField("uuid", uuid).WithField("team", team).Info("Retrieving Authorization header")
+	token := c.GetHeader("Authorization")
+	if token == "" {
+		writeError(c, http.StatusForbidden, ErrorCodeUnauthenticated, "Missing Authorization header")
+		c.Abort()
+		return
+	}
+	c.Set(tokenKey, token)
+	m.Logger.WithField("uuid", uuid).WithField("team", team).Debug("Retrieved Authorization header")
+}
+
+// RequirePermission verifies the policy grants the caller's roles on the team the operation and stores their login,
+// roles and any team ancestry their maintainership was inherited through on the request context
+func (m *Manager) RequirePermission(operation string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		uuid := requestid.Get(c)
+		team := c.GetString(teamKey)
+
+		token := c.GetString(tokenKey)
+		resolved := &caller{}
+		if m.OIDC != nil && IsOIDCToken(token) {
+			m.Logger.WithField("uuid", uuid).WithField("team", team).Info("Verifying OIDC token")
+			claims, err := m.OIDC.Verify(token)
+			if err != nil {
+				writeError(c, http.StatusForbidden, ErrorCodeUnauthenticated, fmt.Sprintf("Unable to validate OIDC token: %v", err))
+				c.Abort()
+				return
+			}
+			resolved.login, _ = claims["sub"].(string)
+			resolved.roles = m.OIDC.Roles(claims, m.Config.Org, team, !m.Config.managesMultipleOrganizations())
+			m.Logger.WithField("uuid", uuid).WithField("team", team).WithField("user", resolved.login).Debug("Verified OIDC token")
+		} else {
+			m.Logger.WithField("uuid", uuid).WithField("team", team).Info("Verifying team membership")
+			var err error
+			resolved, err = m.resolveRoles(token, team, uuid)
+			if err != nil {
+				writeAPIError(c, asAPIError(err, http.StatusForbidden, ErrorCodeNotMaintainer), fmt.Sprintf("Unable to validate user is a team maintainer: %v", err))
+				c.Abort()
+				return
+			}
+			m.Logger.WithField("uuid", uuid).WithField("team", team).WithField("user", resolved.login).Debug("Verified team membership")
+		}
+
+		m.Logger.WithField("uuid", uuid).WithField("team", team).WithField("user", resolved.login).Infof("Authorizing operation %s for roles %v", operation, resolved.roles)
+		if !m.Config.Policy.Allows(resolved.roles, operation) {
+			// The policy may also withhold an operation from maintainers, which is not reported as NOT_MAINTAINER
+			code := ErrorCodeNotMaintainer
+			for _, role := range resolved.roles {
+				if role == RoleMaintainer {
+					code = ErrorCodeForbidden
+				}
+			}
+			writeError(c, http.StatusUnauthorized, code, fmt.Sprintf("User is not authorized to perform %s for the team", operation))
+			c.Abort()
+			return
+		}
+		c.Set(userKey, resolved.login)
+		c.Set(rolesKey, resolved.roles)
+		c.Set(ancestryKey, resolved.ancestry)
+		c.Set(operationKey, operation)
+		m.Logger.WithField("uuid", uuid).WithField("team", team).WithField("user", resolved.login).Debugf("Authorized operation %s", operation)
+	}
+}
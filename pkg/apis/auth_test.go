@@ -0,0 +1,250 @@
+/**
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package apis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/didip/tollbooth/v6"
+	"github.com/gin-gonic/gin"
+	"github.com/google/go-github/v41/github"
+	"github.com/lindluni/actions-runner-manager/pkg/apis/mocks"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthorizationHandlers_Success(t *testing.T) {
+	t.Parallel()
+
+	teamsClient := &mocks.TeamsClient{}
+	teamsClient.GetTeamMembershipBySlugReturns(&github.Membership{Role: github.String("maintainer")}, nil, nil)
+	logger, _ := test.NewNullLogger()
+	manager := &Manager{
+		Config: &Config{},
+		CreateMaintainershipClient: func(string, string) (*MaintainershipClient, *github.User, error) {
+			return &MaintainershipClient{
+				TeamsClient: teamsClient,
+			}, &github.User{Login: github.String("fake-user")}, nil
+		},
+		Logger: logger,
+	}
+
+	router := gin.New()
+	router.GET("/", append(manager.AuthorizationHandlers(), manager.RequirePermission(OperationGroupList), func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{
+			"team": c.GetString(teamKey),
+			"user": c.GetString(userKey),
+		})
+	})...)
+
+	writer := httptest.NewRecorder()
+	request, err := http.NewRequest(http.MethodGet, "/?team=fake-team", nil)
+	require.NoError(t, err)
+	request.Header.Set("Authorization", "test-token")
+	router.ServeHTTP(writer, request)
+
+	result := writer.Result()
+	body, err := ioutil.ReadAll(result.Body)
+	defer result.Body.Close()
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, result.StatusCode)
+	require.JSONEq(t, `{"team":"fake-team","user":"fake-user"}`, string(body))
+}
+
+func TestAuthorizationHandlers_Failure(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		url           string
+		token         string
+		membership    *github.Membership
+		membershipErr error
+		expected      *JSONResultError
+	}{
+		{
+			name:  "missing team",
+			url:   "/api/v1/group-list",
+			token: "test-token",
+			expected: &JSONResultError{
+				Code:      http.StatusBadRequest,
+				Error:     "Missing required parameter: team",
+				ErrorCode: ErrorCodeBadRequest,
+			},
+		},
+		{
+			name: "missing token",
+			url:  "/api/v1/group-list?team=fake-team",
+			expected: &JSONResultError{
+				Code:      http.StatusForbidden,
+				Error:     "Missing Authorization header",
+				ErrorCode: ErrorCodeUnauthenticated,
+			},
+		},
+		{
+			name:          "membership failure",
+			url:           "/api/v1/group-list?team=fake-team",
+			token:         "test-token",
+			membershipErr: fmt.Errorf("fake-error"),
+			expected: &JSONResultError{
+				Code:      http.StatusBadGateway,
+				Error:     "Unable to validate user is a team maintainer: fake-error",
+				ErrorCode: ErrorCodeGitHubUnavailable,
+				Retryable: true,
+			},
+		},
+		{
+			name:       "not a maintainer",
+			url:        "/api/v1/group-list?team=fake-team",
+			token:      "test-token",
+			membership: &github.Membership{Role: github.String("member")},
+			expected: &JSONResultError{
+				Code:      http.StatusUnauthorized,
+				Error:     "User is not authorized to perform group-list for the team",
+				ErrorCode: ErrorCodeNotMaintainer,
+			},
+		},
+	}
+
+	logger, _ := test.NewNullLogger()
+	for _, tc := range tests {
+		actionsClient := &mocks.ActionsClient{}
+		teamsClient := &mocks.TeamsClient{}
+		teamsClient.GetTeamMembershipBySlugReturns(tc.membership, nil, tc.membershipErr)
+		manager := &Manager{
+			ActionsClient: actionsClient,
+			Config:        &Config{},
+			CreateMaintainershipClient: func(string, string) (*MaintainershipClient, *github.User, error) {
+				return &MaintainershipClient{
+					TeamsClient: teamsClient,
+				}, &github.User{Login: github.String("fake-user")}, nil
+			},
+			Limit:  tollbooth.NewLimiter(1, nil),
+			Logger: logger,
+			Router: gin.New(),
+		}
+		manager.SetRoutes()
+
+		writer := httptest.NewRecorder()
+		request, err := http.NewRequest(http.MethodGet, tc.url, nil)
+		require.NoError(t, err, tc.name)
+		if tc.token != "" {
+			request.Header.Set("Authorization", tc.token)
+		}
+		manager.Router.ServeHTTP(writer, request)
+
+		result := writer.Result()
+		body, err := ioutil.ReadAll(result.Body)
+		result.Body.Close()
+		require.NoError(t, err, tc.name)
+
+		response := &JSONResultError{}
+		err = json.Unmarshal(body, response)
+		require.NoError(t, err, tc.name)
+		require.Equal(t, tc.expected, response, tc.name)
+		require.Equal(t, tc.expected.Code, result.StatusCode, tc.name)
+		require.Equal(t, 0, actionsClient.ListOrganizationRunnerGroupsCallCount(), tc.name)
+	}
+}
+
+func TestAuthorizationHandlers_TokenVerification(t *testing.T) {
+	t.Parallel()
+
+	reset := time.Now().Add(time.Minute).Unix()
+	tests := []struct {
+		name      string
+		status    int
+		headers   map[string]string
+		code      int
+		errorCode ErrorCode
+		retryable bool
+	}{
+		{
+			name:      "rejected token",
+			status:    http.StatusUnauthorized,
+			code:      http.StatusForbidden,
+			errorCode: ErrorCodeUnauthenticated,
+		},
+		{
+			name:   "rate limited",
+			status: http.StatusForbidden,
+			headers: map[string]string{
+				"X-RateLimit-Limit":     "5000",
+				"X-RateLimit-Remaining": "0",
+				"X-RateLimit-Reset":     strconv.FormatInt(reset, 10),
+			},
+			code:      http.StatusTooManyRequests,
+			errorCode: ErrorCodeRateLimited,
+			retryable: true,
+		},
+		{
+			name:      "unavailable",
+			status:    http.StatusServiceUnavailable,
+			code:      http.StatusServiceUnavailable,
+			errorCode: ErrorCodeGitHubUnavailable,
+			retryable: true,
+		},
+	}
+
+	logger, _ := test.NewNullLogger()
+	for _, tc := range tests {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			for key, value := range tc.headers {
+				w.Header().Set(key, value)
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(tc.status)
+			_, _ = w.Write([]byte(`{"message":"fake-error"}`))
+		}))
+
+		actionsClient := &mocks.ActionsClient{}
+		manager := &Manager{
+			ActionsClient: actionsClient,
+			Config:        &Config{},
+			CreateMaintainershipClient: func(string, string) (*MaintainershipClient, *github.User, error) {
+				client, err := GitHub{BaseURL: server.URL + "/api/v3"}.NewClient(server.Client())
+				require.NoError(t, err, tc.name)
+				user, resp, err := client.Users.Get(context.Background(), "")
+				if err != nil {
+					return nil, nil, TokenError(resp, err)
+				}
+				return &MaintainershipClient{TeamsClient: client.Teams}, user, nil
+			},
+			Limit:  tollbooth.NewLimiter(1, nil),
+			Logger: logger,
+			Router: gin.New(),
+		}
+		manager.SetRoutes()
+
+		writer := httptest.NewRecorder()
+		request, err := http.NewRequest(http.MethodGet, "/api/v1/group-list?team=fake-team", nil)
+		require.NoError(t, err, tc.name)
+		request.Header.Set("Authorization", "test-token")
+		manager.Router.ServeHTTP(writer, request)
+		server.Close()
+
+		result := writer.Result()
+		body, err := ioutil.ReadAll(result.Body)
+		result.Body.Close()
+		require.NoError(t, err, tc.name)
+
+		response := &JSONResultError{}
+		err = json.Unmarshal(body, response)
+		require.NoError(t, err, tc.name)
+		require.Equal(t, tc.code, result.StatusCode, tc.name)
+		require.Equal(t, tc.code, response.Code, tc.name)
+		require.Equal(t, tc.errorCode, response.ErrorCode, tc.name)
+		require.Equal(t, tc.retryable, response.Retryable, tc.name)
+		require.Equal(t, 0, actionsClient.ListOrganizationRunnerGroupsCallCount(), tc.name)
+	}
+}
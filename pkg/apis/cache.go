@@ -0,0 +1,148 @@
+/**
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package apis
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+	"time"
+)
+
+// MaintainershipCache stores the roles resolved for a caller keyed on a hash of the callers token and the
+// team slug so repeated requests do not have to call the GitHub API. A nil cache is valid and caches nothing.
+type MaintainershipCache struct {
+	ttl         time.Duration
+	negativeTTL time.Duration
+	now         func() time.Time
+
+	mutex     sync.Mutex
+	entries   map[string]*maintainershipEntry
+	nextSweep time.Time
+}
+
+type maintainershipEntry struct {
+	team      string
+	caller    *caller
+	expiresAt time.Time
+}
+
+// NewMaintainershipCache creates a cache that keeps the roles of team members for ttl and results for callers without
+// any role for negativeTTL. If negativeTTL is zero, ttl is used for both.
+func NewMaintainershipCache(ttl, negativeTTL time.Duration) *MaintainershipCache {
+	if negativeTTL == 0 {
+		negativeTTL = ttl
+	}
+	return &MaintainershipCache{
+		ttl:         ttl,
+		negativeTTL: negativeTTL,
+		now:         time.Now,
+		entries:     map[string]*maintainershipEntry{},
+	}
+}
+
+// Get returns the caller cached for the token and team if an unexpired entry exists
+func (mc *MaintainershipCache) Get(token, team string) (*caller, bool) {
+	if mc == nil {
+		return nil, false
+	}
+	mc.mutex.Lock()
+	defer mc.mutex.Unlock()
+
+	key := cacheKey(token, team)
+	entry, ok := mc.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if !mc.now().Before(entry.expiresAt) {
+		delete(mc.entries, key)
+		return nil, false
+	}
+	return entry.caller, true
+}
+
+// Set caches the caller resolved for the token and team
+func (mc *MaintainershipCache) Set(token, team string, resolved *caller) {
+	if mc == nil {
+		return
+	}
+	mc.mutex.Lock()
+	defer mc.mutex.Unlock()
+
+	now := mc.now()
+	ttl := mc.ttl
+	if len(resolved.roles) == 0 {
+		ttl = mc.negativeTTL
+	}
+	if ttl <= 0 {
+		return
+	}
+	mc.sweep(now)
+	mc.entries[cacheKey(token, team)] = &maintainershipEntry{
+		team:      team,
+		caller:    resolved,
+		expiresAt: now.Add(ttl),
+	}
+}
+
+// Invalidate removes every cached result for the team, or every cached result for the user of the team when user is
+// not empty
+func (mc *MaintainershipCache) Invalidate(team, user string) {
+	if mc == nil {
+		return
+	}
+	mc.mutex.Lock()
+	defer mc.mutex.Unlock()
+
+	for key, entry := range mc.entries {
+		if entry.team == team && (user == "" || entry.caller.login == user) {
+			delete(mc.entries, key)
+		}
+	}
+}
+
+// InvalidateUser removes every cached result for the user, whatever the team
+func (mc *MaintainershipCache) InvalidateUser(user string) {
+	if mc == nil {
+		return
+	}
+	mc.mutex.Lock()
+	defer mc.mutex.Unlock()
+
+	for key, entry := range mc.entries {
+		if entry.caller.login == user {
+			delete(mc.entries, key)
+		}
+	}
+}
+
+// InvalidateAll removes every cached result
+func (mc *MaintainershipCache) InvalidateAll() {
+	if mc == nil {
+		return
+	}
+	mc.mutex.Lock()
+	defer mc.mutex.Unlock()
+
+	mc.entries = map[string]*maintainershipEntry{}
+}
+
+// sweep removes expired entries at most once per ttl so abandoned tokens do not accumulate, the caller must hold the lock
+func (mc *MaintainershipCache) sweep(now time.Time) {
+	if now.Before(mc.nextSweep) {
+		return
+	}
+	for key, entry := range mc.entries {
+		if !now.Before(entry.expiresAt) {
+			delete(mc.entries, key)
+		}
+	}
+	mc.nextSweep = now.Add(mc.ttl)
+}
+
+func cacheKey(token, team string) string {
+	hash := sha256.Sum256([]byte(token + "\x00" + team))
+	return hex.EncodeToString(hash[:])
+}
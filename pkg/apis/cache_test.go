@@ -0,0 +1,85 @@
+/**
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package apis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaintainershipCache(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(0, 0)
+	cache := NewMaintainershipCache(time.Minute, time.Second)
+	cache.now = func() time.Time { return now }
+
+	cache.Set("maintainer-token", "fake-team", &caller{login: "maintainer", roles: []string{RoleMaintainer}})
+	cache.Set("member-token", "fake-team", &caller{login: "member"})
+	cache.Set("maintainer-token", "other-team", &caller{login: "maintainer", roles: []string{RoleMaintainer}})
+
+	cached, ok := cache.Get("maintainer-token", "fake-team")
+	require.True(t, ok)
+	require.Equal(t, []string{RoleMaintainer}, cached.roles)
+	require.Equal(t, "maintainer", cached.login)
+
+	cached, ok = cache.Get("member-token", "fake-team")
+	require.True(t, ok)
+	require.Empty(t, cached.roles)
+	require.Equal(t, "member", cached.login)
+
+	_, ok = cache.Get("member-token", "other-team")
+	require.False(t, ok)
+
+	now = now.Add(2 * time.Second)
+	_, ok = cache.Get("member-token", "fake-team")
+	require.False(t, ok, "results without roles expire after the negative ttl")
+	_, ok = cache.Get("maintainer-token", "fake-team")
+	require.True(t, ok)
+
+	cache.Invalidate("fake-team", "")
+	_, ok = cache.Get("maintainer-token", "fake-team")
+	require.False(t, ok)
+	_, ok = cache.Get("maintainer-token", "other-team")
+	require.True(t, ok)
+
+	cache.Set("maintainer-token", "fake-team", &caller{login: "maintainer", roles: []string{RoleMaintainer}})
+	cache.Invalidate("fake-team", "someone-else")
+	_, ok = cache.Get("maintainer-token", "fake-team")
+	require.True(t, ok)
+
+	now = now.Add(time.Minute)
+	_, ok = cache.Get("maintainer-token", "fake-team")
+	require.False(t, ok)
+
+	cache.Set("maintainer-token", "fake-team", &caller{login: "maintainer", roles: []string{RoleMaintainer}})
+	cache.Set("maintainer-token", "child-team", &caller{login: "maintainer", roles: []string{RoleMaintainer}})
+	cache.Set("member-token", "fake-team", &caller{login: "member", roles: []string{RoleMember}})
+	cache.InvalidateUser("maintainer")
+	_, ok = cache.Get("maintainer-token", "fake-team")
+	require.False(t, ok)
+	_, ok = cache.Get("maintainer-token", "child-team")
+	require.False(t, ok)
+	_, ok = cache.Get("member-token", "fake-team")
+	require.True(t, ok)
+
+	cache.InvalidateAll()
+	_, ok = cache.Get("maintainer-token", "other-team")
+	require.False(t, ok)
+}
+
+func TestMaintainershipCache_Nil(t *testing.T) {
+	t.Parallel()
+
+	var cache *MaintainershipCache
+	cache.Set("token", "fake-team", &caller{login: "user", roles: []string{RoleMaintainer}})
+	_, ok := cache.Get("token", "fake-team")
+	require.False(t, ok)
+	cache.Invalidate("fake-team", "")
+	cache.InvalidateUser("user")
+	cache.InvalidateAll()
+}
@@ -0,0 +1,61 @@
+/**
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package apis
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// dryRunDiff is the change a mutating call would make, returned in place of making it when the dry_run parameter is set
+type dryRunDiff struct {
+	DryRun       bool          `json:"dryRun"`
+	GroupCreated string        `json:"groupCreated,omitempty"`
+	GroupDeleted string        `json:"groupDeleted,omitempty"`
+	ReposAdded   []string      `json:"reposAdded,omitempty"`
+	ReposRemoved []string      `json:"reposRemoved,omitempty"`
+	Results      []*repoResult `json:"results,omitempty"`
+}
+
+// retrieveDryRun parses the optional dry_run parameter, writing the error response and returning false if it is not a
+// boolean
+func retrieveDryRun(c *gin.Context) (bool, bool) {
+	param, ok := c.GetQuery("dry_run")
+	if !ok || param == "" {
+		return false, true
+	}
+	dryRun, err := strconv.ParseBool(param)
+	if err != nil {
+		writeError(c, http.StatusBadRequest, ErrorCodeBadRequest, fmt.Sprintf("Invalid dry_run %s, must be true or false", param))
+		return false, false
+	}
+	return dryRun, true
+}
+
+// isDryRun reports whether the request asks for a dry run, treating an invalid dry_run parameter as a dry run since
+// the handler rejects it without making any change
+func isDryRun(c *gin.Context) bool {
+	param := c.Query("dry_run")
+	if param == "" {
+		return false
+	}
+	dryRun, err := strconv.ParseBool(param)
+	return dryRun || err != nil
+}
+
+// writeDryRun responds with the change the call would have made
+func writeDryRun(c *gin.Context, code int, diff *dryRunDiff) {
+	diff.DryRun = true
+	sort.Strings(diff.ReposAdded)
+	sort.Strings(diff.ReposRemoved)
+	c.JSON(code, &JSONResultSuccess{
+		Code:     code,
+		Response: diff,
+	})
+}
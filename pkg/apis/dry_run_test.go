@@ -0,0 +1,137 @@
+/**
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package apis
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/lindluni/actions-runner-manager/pkg/apis/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDryRun(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		code   int
+		error  string
+		diff   *dryRunDiff
+	}{
+		{
+			name:   "group-create",
+			method: http.MethodPost,
+			path:   "/api/v1/group-create?team=other-team&dry_run=true&workflows=fake-org/fake-repo/.github/workflows/build.yml@main",
+			code:   http.StatusOK,
+			diff:   &dryRunDiff{DryRun: true, GroupCreated: "other-team"},
+		},
+		{
+			name:   "group-create existing",
+			method: http.MethodPost,
+			path:   "/api/v1/group-create?team=fake-team&dry_run=true",
+			code:   http.StatusConflict,
+			error:  "Runner group already exists: fake-team",
+		},
+		{
+			name:   "group-delete",
+			method: http.MethodDelete,
+			path:   "/api/v1/group-delete?team=fake-team&dry_run=1",
+			code:   http.StatusOK,
+			diff:   &dryRunDiff{DryRun: true, GroupDeleted: "fake-team"},
+		},
+		{
+			name:   "repos-add",
+			method: http.MethodPatch,
+			path:   "/api/v1/repos-add?team=fake-team&dry_run=true&repos=new,present,other",
+			code:   http.StatusMultiStatus,
+			diff: &dryRunDiff{
+				DryRun:     true,
+				ReposAdded: []string{"new"},
+				Results: []*repoResult{
+					{Repo: "new", Status: RepoStatusAdded},
+					{Repo: "present", Status: RepoStatusAlreadyPresent},
+					{Repo: "other", Status: RepoStatusNotInTeam},
+				},
+			},
+		},
+		{
+			name:   "repos-remove",
+			method: http.MethodPatch,
+			path:   "/api/v1/repos-remove?team=fake-team&dry_run=true&repos=present,other",
+			code:   http.StatusOK,
+			diff: &dryRunDiff{
+				DryRun:       true,
+				ReposRemoved: []string{"present"},
+				Results: []*repoResult{
+					{Repo: "present", Status: RepoStatusRemoved},
+					{Repo: "other", Status: RepoStatusNotPresent},
+				},
+			},
+		},
+		{
+			name:   "repos-set",
+			method: http.MethodPatch,
+			path:   "/api/v1/repos-set?team=fake-team&dry_run=true&repos=new,present",
+			code:   http.StatusOK,
+			diff:   &dryRunDiff{DryRun: true, ReposAdded: []string{"new"}, ReposRemoved: []string{"broken"}},
+		},
+		{
+			name:   "invalid dry_run",
+			method: http.MethodPatch,
+			path:   "/api/v1/repos-set?team=fake-team&dry_run=maybe&repos=new",
+			code:   http.StatusBadRequest,
+			error:  "Invalid dry_run maybe, must be true or false",
+		},
+	}
+
+	for _, tc := range tests {
+		actionsClient := &mocks.ActionsClient{}
+		manager := newReposManager(actionsClient)
+		restClient := &mocks.RestClient{}
+		manager.RestClient = restClient
+		sink, err := NewFileAuditSink(filepath.Join(t.TempDir(), "audit.jsonl"))
+		require.NoError(t, err, tc.name)
+		manager.AuditLog = sink
+
+		writer := httptest.NewRecorder()
+		request, err := http.NewRequest(tc.method, tc.path, nil)
+		require.NoError(t, err, tc.name)
+		request.Header.Set("Authorization", "test-token")
+		manager.Router.ServeHTTP(writer, request)
+		require.Equal(t, tc.code, writer.Code, tc.name)
+
+		if tc.error != "" {
+			response := &JSONResultError{}
+			require.NoError(t, json.Unmarshal(writer.Body.Bytes(), response), tc.name)
+			require.Equal(t, tc.error, response.Error, tc.name)
+		} else {
+			response := &struct {
+				Code     int
+				Response *dryRunDiff
+			}{}
+			require.NoError(t, json.Unmarshal(writer.Body.Bytes(), response), tc.name)
+			require.Equal(t, tc.diff, response.Response, tc.name)
+		}
+
+		require.Equal(t, 0, actionsClient.CreateOrganizationRunnerGroupCallCount(), tc.name)
+		require.Equal(t, 0, actionsClient.DeleteOrganizationRunnerGroupCallCount(), tc.name)
+		require.Equal(t, 0, actionsClient.UpdateOrganizationRunnerGroupCallCount(), tc.name)
+		require.Equal(t, 0, actionsClient.AddRepositoryAccessRunnerGroupCallCount(), tc.name)
+		require.Equal(t, 0, actionsClient.RemoveRepositoryAccessRunnerGroupCallCount(), tc.name)
+		require.Equal(t, 0, actionsClient.SetRepositoryAccessRunnerGroupCallCount(), tc.name)
+		require.Equal(t, 0, restClient.DoCallCount(), tc.name)
+
+		events, err := sink.Query(&AuditFilter{})
+		require.NoError(t, err, tc.name)
+		require.NoError(t, sink.Close(), tc.name)
+		require.Empty(t, events, tc.name)
+	}
+}
@@ -0,0 +1,183 @@
+/**
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package apis
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-contrib/requestid"
+	"github.com/gin-gonic/gin"
+	"github.com/google/go-github/v41/github"
+)
+
+// ErrorCode is a stable, machine readable identifier of the cause of an error response, which clients can branch on
+// instead of parsing the error message
+type ErrorCode string
+
+const (
+	// ErrorCodeBadRequest reports a missing or invalid parameter or request body
+	ErrorCodeBadRequest ErrorCode = "BAD_REQUEST"
+	// ErrorCodeUnauthenticated reports a missing or invalid token
+	ErrorCodeUnauthenticated ErrorCode = "UNAUTHENTICATED"
+	// ErrorCodeForbidden reports an operation the policy does not grant the roles of the caller, or a limit or
+	// restriction of the configuration
+	ErrorCodeForbidden ErrorCode = "FORBIDDEN"
+	// ErrorCodeNotMaintainer reports a caller that does not hold any role on the team
+	ErrorCodeNotMaintainer ErrorCode = "NOT_MAINTAINER"
+	// ErrorCodeTeamNotFound reports a team that does not exist in the organization
+	ErrorCodeTeamNotFound ErrorCode = "TEAM_NOT_FOUND"
+	// ErrorCodeGroupNotFound reports a team that does not have the runner group
+	ErrorCodeGroupNotFound ErrorCode = "GROUP_NOT_FOUND"
+	// ErrorCodeRepoNotInTeam reports a repository the team does not have access to
+	ErrorCodeRepoNotInTeam ErrorCode = "REPO_NOT_IN_TEAM"
+	// ErrorCodeNotFound reports any other resource that does not exist
+	ErrorCodeNotFound ErrorCode = "NOT_FOUND"
+	// ErrorCodeConflict reports a resource that already exists or is in use
+	ErrorCodeConflict ErrorCode = "CONFLICT"
+	// ErrorCodeRateLimited reports a request rejected by the rate limit of the server or of the GitHub API
+	ErrorCodeRateLimited ErrorCode = "RATE_LIMITED"
+	// ErrorCodeGitHubUnavailable reports a GitHub API that could not be reached or failed to serve the request
+	ErrorCodeGitHubUnavailable ErrorCode = "GITHUB_UNAVAILABLE"
+	// ErrorCodeGitHubError reports any other error returned by the GitHub API
+	ErrorCodeGitHubError ErrorCode = "GITHUB_ERROR"
+	// ErrorCodeInternal reports an error of the server
+	ErrorCodeInternal ErrorCode = "INTERNAL_ERROR"
+)
+
+// JSONResultError is the body of every error response. Code and Error are kept for existing clients, ErrorCode
+// identifies the cause of the error, and Retryable reports whether the same request may succeed if sent again, after
+// RetryAfter seconds when it is set.
+type JSONResultError struct {
+	Code       int       `json:"Code" `
+	Error      string    `json:"Error"`
+	ErrorCode  ErrorCode `json:"ErrorCode,omitempty"`
+	RequestID  string    `json:"RequestID,omitempty"`
+	Retryable  bool      `json:"Retryable"`
+	RetryAfter int       `json:"RetryAfter,omitempty"`
+}
+
+// apiError is an error carrying the status, error code and retry hints of the response it results in
+type apiError struct {
+	status     int
+	code       ErrorCode
+	retryable  bool
+	retryAfter time.Duration
+	err        error
+}
+
+func (e *apiError) Error() string {
+	if e.err == nil {
+		return string(e.code)
+	}
+	return e.err.Error()
+}
+
+func (e *apiError) Unwrap() error {
+	return e.err
+}
+
+// newAPIError wraps err with the status and error code of the response it results in
+func newAPIError(status int, code ErrorCode, err error) *apiError {
+	return &apiError{
+		status:    status,
+		code:      code,
+		retryable: isRetryableStatus(status),
+		err:       err,
+	}
+}
+
+// gitHubError maps an error returned by a GitHub API call to the response it results in. Rate limits are reported as
+// retryable RATE_LIMITED errors along with when to retry, and calls that did not receive a response or received a
+// server error as retryable GITHUB_UNAVAILABLE errors. Any other status is passed through.
+func gitHubError(resp *github.Response, err error) *apiError {
+	var rateLimitErr *github.RateLimitError
+	if errors.As(err, &rateLimitErr) {
+		apiErr := newAPIError(http.StatusTooManyRequests, ErrorCodeRateLimited, err)
+		apiErr.retryAfter = time.Until(rateLimitErr.Rate.Reset.Time)
+		return apiErr
+	}
+	var abuseErr *github.AbuseRateLimitError
+	if errors.As(err, &abuseErr) {
+		apiErr := newAPIError(http.StatusTooManyRequests, ErrorCodeRateLimited, err)
+		apiErr.retryAfter = abuseErr.GetRetryAfter()
+		return apiErr
+	}
+	if resp == nil || resp.Response == nil {
+		return newAPIError(http.StatusBadGateway, ErrorCodeGitHubUnavailable, err)
+	}
+
+	status := resp.StatusCode
+	switch {
+	case status >= http.StatusInternalServerError:
+		return newAPIError(status, ErrorCodeGitHubUnavailable, err)
+	case status == http.StatusNotFound:
+		return newAPIError(status, ErrorCodeNotFound, err)
+	case status == http.StatusConflict:
+		return newAPIError(status, ErrorCodeConflict, err)
+	case status == http.StatusUnauthorized:
+		return newAPIError(status, ErrorCodeUnauthenticated, err)
+	case status == http.StatusForbidden:
+		return newAPIError(status, ErrorCodeForbidden, err)
+	case status < http.StatusBadRequest:
+		// A call that failed after a successful response, such as one whose body could not be decoded
+		return newAPIError(http.StatusBadGateway, ErrorCodeGitHubError, err)
+	}
+	return newAPIError(status, ErrorCodeGitHubError, err)
+}
+
+// TokenError maps the failure to verify the token of a caller with the GitHub API. Rejected tokens are reported as
+// unauthenticated, while rate limits and outages of the GitHub API keep their status so that clients may retry
+func TokenError(resp *github.Response, err error) error {
+	apiErr := gitHubError(resp, err)
+	if apiErr.status == http.StatusUnauthorized || apiErr.status == http.StatusForbidden {
+		return newAPIError(http.StatusForbidden, ErrorCodeUnauthenticated, err)
+	}
+	return apiErr
+}
+
+// asAPIError returns the apiError wrapped by err, or wraps err with the status and error code when it does not carry one
+func asAPIError(err error, status int, code ErrorCode) *apiError {
+	var apiErr *apiError
+	if errors.As(err, &apiErr) {
+		return apiErr
+	}
+	return newAPIError(status, code, err)
+}
+
+// isRetryableStatus reports whether a request that failed with the status may succeed if sent again
+func isRetryableStatus(status int) bool {
+	switch status {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// writeError responds with an error that is not caused by a GitHub API call
+func writeError(c *gin.Context, status int, code ErrorCode, message string) {
+	writeAPIError(c, newAPIError(status, code, nil), message)
+}
+
+// writeAPIError responds with the error carried by err, which is reported as an internal error if it does not carry the
+// response it results in. A Retry-After header is set on retryable errors that know when to retry.
+func writeAPIError(c *gin.Context, err error, message string) {
+	apiErr := asAPIError(err, http.StatusInternalServerError, ErrorCodeInternal)
+	result := &JSONResultError{
+		Code:      apiErr.status,
+		Error:     message,
+		ErrorCode: apiErr.code,
+		RequestID: requestid.Get(c),
+		Retryable: apiErr.retryable,
+	}
+	if apiErr.retryable && apiErr.retryAfter > 0 {
+		result.RetryAfter = int(math.Ceil(apiErr.retryAfter.Seconds()))
+		c.Header("Retry-After", strconv.Itoa(result.RetryAfter))
+	}
+	c.JSON(apiErr.status, result)
+}
@@ -0,0 +1,140 @@
+/**
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package apis
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/go-github/v41/github"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGitHubError(t *testing.T) {
+	t.Parallel()
+
+	retryAfter := 30 * time.Second
+	tests := []struct {
+		name       string
+		resp       *github.Response
+		err        error
+		status     int
+		code       ErrorCode
+		retryable  bool
+		retryAfter bool
+	}{
+		{
+			name: "rate limit",
+			err: &github.RateLimitError{
+				Rate: github.Rate{Reset: github.Timestamp{Time: time.Now().Add(time.Minute)}},
+			},
+			status:     http.StatusTooManyRequests,
+			code:       ErrorCodeRateLimited,
+			retryable:  true,
+			retryAfter: true,
+		},
+		{
+			name:       "abuse rate limit",
+			err:        fmt.Errorf("unable to list runners: %w", &github.AbuseRateLimitError{RetryAfter: &retryAfter}),
+			status:     http.StatusTooManyRequests,
+			code:       ErrorCodeRateLimited,
+			retryable:  true,
+			retryAfter: true,
+		},
+		{
+			name:      "no response",
+			err:       errors.New("connection reset"),
+			status:    http.StatusBadGateway,
+			code:      ErrorCodeGitHubUnavailable,
+			retryable: true,
+		},
+		{
+			name:      "server error",
+			resp:      &github.Response{Response: &http.Response{StatusCode: http.StatusServiceUnavailable}},
+			err:       errors.New("service unavailable"),
+			status:    http.StatusServiceUnavailable,
+			code:      ErrorCodeGitHubUnavailable,
+			retryable: true,
+		},
+		{
+			name:   "not found",
+			resp:   &github.Response{Response: &http.Response{StatusCode: http.StatusNotFound}},
+			err:    errors.New("not found"),
+			status: http.StatusNotFound,
+			code:   ErrorCodeNotFound,
+		},
+		{
+			name:   "validation failed",
+			resp:   &github.Response{Response: &http.Response{StatusCode: http.StatusUnprocessableEntity}},
+			err:    errors.New("validation failed"),
+			status: http.StatusUnprocessableEntity,
+			code:   ErrorCodeGitHubError,
+		},
+	}
+
+	for _, tc := range tests {
+		apiErr := gitHubError(tc.resp, tc.err)
+		require.Equal(t, tc.status, apiErr.status, tc.name)
+		require.Equal(t, tc.code, apiErr.code, tc.name)
+		require.Equal(t, tc.retryable, apiErr.retryable, tc.name)
+		require.Equal(t, tc.retryAfter, apiErr.retryAfter > 0, tc.name)
+		require.ErrorIs(t, apiErr, tc.err, tc.name)
+	}
+}
+
+func TestWriteAPIError(t *testing.T) {
+	t.Parallel()
+
+	retryAfter := 90 * time.Second
+	tests := []struct {
+		name     string
+		err      error
+		header   string
+		expected *JSONResultError
+	}{
+		{
+			name:   "rate limited",
+			err:    fmt.Errorf("unable to list runners: %w", gitHubError(nil, &github.AbuseRateLimitError{RetryAfter: &retryAfter})),
+			header: "90",
+			expected: &JSONResultError{
+				Code:       http.StatusTooManyRequests,
+				Error:      "Unable to list runners",
+				ErrorCode:  ErrorCodeRateLimited,
+				RequestID:  "fake-uuid",
+				Retryable:  true,
+				RetryAfter: 90,
+			},
+		},
+		{
+			name: "internal",
+			err:  errors.New("fake-error"),
+			expected: &JSONResultError{
+				Code:      http.StatusInternalServerError,
+				Error:     "Unable to list runners",
+				ErrorCode: ErrorCodeInternal,
+				RequestID: "fake-uuid",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		writer := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(writer)
+		c.Writer.Header().Set("X-Request-ID", "fake-uuid")
+		writeAPIError(c, tc.err, "Unable to list runners")
+		require.Equal(t, tc.expected.Code, writer.Code, tc.name)
+		require.Equal(t, tc.header, writer.Header().Get("Retry-After"), tc.name)
+
+		result := &JSONResultError{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), result), tc.name)
+		require.Equal(t, tc.expected, result, tc.name)
+	}
+}
@@ -0,0 +1,87 @@
+/**
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package apis
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/google/go-github/v41/github"
+)
+
+const defaultGitHubBaseURL = "https://api.github.com/"
+
+// GitHub configures the GitHub API the server talks to. Both URLs default to the public api.github.com endpoints. For
+// GitHub Enterprise Server, BaseURL is usually https://<hostname>/api/v3/ and UploadURL https://<hostname>/api/uploads/.
+type GitHub struct {
+	BaseURL   string `yaml:"baseURL"`
+	UploadURL string `yaml:"uploadURL"`
+}
+
+// Validate verifies the configured URLs are absolute HTTP or HTTPS URLs
+func (g GitHub) Validate() error {
+	for name, value := range map[string]string{"baseURL": g.BaseURL, "uploadURL": g.UploadURL} {
+		if value == "" {
+			continue
+		}
+		if _, err := parseGitHubURL(value); err != nil {
+			return fmt.Errorf("invalid %s: %w", name, err)
+		}
+	}
+	if g.BaseURL == "" && g.UploadURL != "" {
+		return fmt.Errorf("uploadURL requires baseURL to be set")
+	}
+	return nil
+}
+
+// NewClient creates a GitHub client that sends requests through the HTTP client to the configured API. When only
+// BaseURL is configured, uploads are sent to it as well.
+func (g GitHub) NewClient(httpClient *http.Client) (*github.Client, error) {
+	client := github.NewClient(httpClient)
+	if g.BaseURL == "" {
+		return client, nil
+	}
+
+	baseURL, err := parseGitHubURL(g.BaseURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid baseURL: %w", err)
+	}
+	uploadURL := baseURL
+	if g.UploadURL != "" {
+		uploadURL, err = parseGitHubURL(g.UploadURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid uploadURL: %w", err)
+		}
+	}
+	client.BaseURL = baseURL
+	client.UploadURL = uploadURL
+	return client, nil
+}
+
+// APIURL returns the configured base URL without a trailing slash, as expected by the BaseURL of the ghinstallation
+// transport that mints installation tokens
+func (g GitHub) APIURL() string {
+	if g.BaseURL == "" {
+		return strings.TrimSuffix(defaultGitHubBaseURL, "/")
+	}
+	return strings.TrimSuffix(g.BaseURL, "/")
+}
+
+// parseGitHubURL parses an absolute HTTP or HTTPS URL, adding the trailing slash go-github requires of its base URLs
+func parseGitHubURL(value string) (*url.URL, error) {
+	parsed, err := url.Parse(value)
+	if err != nil {
+		return nil, err
+	}
+	if parsed.Scheme != "http" && parsed.Scheme != "https" || parsed.Host == "" {
+		return nil, fmt.Errorf("%s is not an absolute HTTP or HTTPS URL", value)
+	}
+	if !strings.HasSuffix(parsed.Path, "/") {
+		parsed.Path += "/"
+	}
+	return parsed, nil
+}
@@ -0,0 +1,65 @@
+/**
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package apis
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGitHub_Validate(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, GitHub{}.Validate())
+	require.NoError(t, GitHub{BaseURL: "https://ghes.example.com/api/v3", UploadURL: "https://ghes.example.com/api/uploads/"}.Validate())
+	require.EqualError(t, GitHub{BaseURL: "ghes.example.com"}.Validate(), "invalid baseURL: ghes.example.com is not an absolute HTTP or HTTPS URL")
+	require.EqualError(t, GitHub{UploadURL: "https://ghes.example.com/api/uploads/"}.Validate(), "uploadURL requires baseURL to be set")
+}
+
+func TestGitHub_NewClient(t *testing.T) {
+	t.Parallel()
+
+	client, err := GitHub{}.NewClient(nil)
+	require.NoError(t, err)
+	require.Equal(t, "https://api.github.com/", client.BaseURL.String())
+	require.Equal(t, "https://api.github.com", GitHub{}.APIURL())
+
+	config := GitHub{BaseURL: "https://ghes.example.com/api/v3", UploadURL: "https://ghes.example.com/api/uploads"}
+	client, err = config.NewClient(nil)
+	require.NoError(t, err)
+	require.Equal(t, "https://ghes.example.com/api/v3/", client.BaseURL.String())
+	require.Equal(t, "https://ghes.example.com/api/uploads/", client.UploadURL.String())
+	require.Equal(t, "https://ghes.example.com/api/v3", config.APIURL())
+
+	config = GitHub{BaseURL: "https://ghes.example.com/api/v3/"}
+	client, err = config.NewClient(nil)
+	require.NoError(t, err)
+	require.Equal(t, "https://ghes.example.com/api/v3/", client.UploadURL.String())
+	require.Equal(t, "https://ghes.example.com/api/v3", config.APIURL())
+}
+
+func TestGitHub_NewClient_FakeServer(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v3/user" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"login":"fake-user"}`))
+	}))
+	defer server.Close()
+
+	client, err := GitHub{BaseURL: server.URL + "/api/v3"}.NewClient(server.Client())
+	require.NoError(t, err)
+	user, _, err := client.Users.Get(context.Background(), "")
+	require.NoError(t, err)
+	require.Equal(t, "fake-user", user.GetLogin())
+}
@@ -0,0 +1,320 @@
+/**
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package apis
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/go-github/v41/github"
+	"github.com/google/uuid"
+)
+
+const (
+	// DefaultGroupNameTemplate names runner groups after the team slug, followed by the group suffix if there is one
+	DefaultGroupNameTemplate = "{{.Team}}{{with .Suffix}}.{{.}}{{end}}"
+
+	migrationUser      = "migration"
+	migrationOperation = "group-rename"
+
+	// groupNameSentinel and groupSuffixSentinel stand in for the team slug and group suffix when a template is inverted
+	// to find the team of a runner group
+	groupNameSentinel   = "\x00team\x00"
+	groupSuffixSentinel = "\x00suffix\x00"
+
+	teamSlugPattern    = `[a-z0-9_-]+`
+	groupSuffixPattern = `[a-z0-9][a-z0-9-]*`
+)
+
+var (
+	// groupSuffixRegexp matches the suffixes that tell the runner groups of a team apart
+	groupSuffixRegexp = regexp.MustCompile(`^` + groupSuffixPattern + `$`)
+	// groupSeparatorRegexp matches text between the team slug and group suffix that does not separate them, as it could
+	// be part of either
+	groupSeparatorRegexp = regexp.MustCompile(`^[a-z0-9_-]*$`)
+)
+
+// groupNameData is the data the runner group naming template is executed with
+type groupNameData struct {
+	Org    string
+	Team   string
+	Suffix string
+}
+
+// GroupRename records the rename of a runner group by MigrateGroupNames
+type GroupRename struct {
+	Org   string `json:"org"`
+	Team  string `json:"team"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+	Error string `json:"error,omitempty"`
+}
+
+// executeGroupNameTemplate executes the naming template, which defaults to DefaultGroupNameTemplate when empty
+func executeGroupNameTemplate(text, org, team, suffix string) (string, error) {
+	if text == "" {
+		text = DefaultGroupNameTemplate
+	}
+	tmpl, err := template.New("group").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", err
+	}
+	var name strings.Builder
+	if err := tmpl.Execute(&name, &groupNameData{Org: org, Team: team, Suffix: suffix}); err != nil {
+		return "", err
+	}
+	return name.String(), nil
+}
+
+// validateGroupNameTemplate verifies the template executes and includes the team slug exactly once, so the team of a
+// runner group can be recovered from its name, and the group suffix at most once and separated from the team slug, so
+// the runner group of a team with a suffix is never named like the default runner group of another team
+func validateGroupNameTemplate(text string) error {
+	for _, suffix := range []string{"", groupSuffixSentinel} {
+		name, err := executeGroupNameTemplate(text, "org", groupNameSentinel, suffix)
+		if err != nil {
+			return fmt.Errorf("invalid group name template %s: %w", text, err)
+		}
+		if strings.Count(name, groupNameSentinel) != 1 {
+			return fmt.Errorf("group name template %s must include {{.Team}} exactly once", text)
+		}
+		if strings.Count(name, groupSuffixSentinel) > 1 {
+			return fmt.Errorf("group name template %s must include {{.Suffix}} at most once", text)
+		}
+		if suffix != "" && !separatesGroupSuffix(name) {
+			return fmt.Errorf("group name template %s must separate {{.Suffix}} from {{.Team}} with a character other than a lowercase letter, digit, underscore or hyphen", text)
+		}
+	}
+	return nil
+}
+
+// separatesGroupSuffix reports whether the team slug and group suffix of the name executed with the sentinels are
+// separated by a character neither of them can contain
+func separatesGroupSuffix(name string) bool {
+	teamStart := strings.Index(name, groupNameSentinel)
+	suffixStart := strings.Index(name, groupSuffixSentinel)
+	switch {
+	case suffixStart < 0:
+		return true
+	case suffixStart > teamStart:
+		return !groupSeparatorRegexp.MatchString(name[teamStart+len(groupNameSentinel) : suffixStart])
+	default:
+		return !groupSeparatorRegexp.MatchString(name[suffixStart+len(groupSuffixSentinel) : teamStart])
+	}
+}
+
+// supportsGroupSuffix reports whether the template names the runner groups of a team differently for each suffix
+func supportsGroupSuffix(text string) bool {
+	name, err := executeGroupNameTemplate(text, "org", groupNameSentinel, groupSuffixSentinel)
+	return err == nil && strings.Contains(name, groupSuffixSentinel)
+}
+
+// isGroupSuffix reports whether the suffix can tell the runner groups of a team apart
+func isGroupSuffix(suffix string) bool {
+	return groupSuffixRegexp.MatchString(suffix)
+}
+
+// GroupName returns the name of the runner group of the team in the organization with the suffix, where the empty
+// suffix names the default runner group of the team
+func (g Groups) GroupName(org, team, suffix string) (string, error) {
+	return executeGroupNameTemplate(g.NameTemplate, org, team, suffix)
+}
+
+// TeamFor returns the team and suffix a runner group is named after, or false if the name does not follow the naming
+// template
+func (g Groups) TeamFor(org, name string) (string, string, bool) {
+	return teamForGroupName(g.NameTemplate, org, name)
+}
+
+// checkGroupOwner verifies the runner group name is attributed to the team and suffix it was named for, so a runner
+// group of another team that the naming template happens to give the same name is never acted on
+func (g Groups) checkGroupOwner(org, name, team, suffix string) error {
+	owner, ownerSuffix, ok := g.TeamFor(org, name)
+	if !ok || owner != team || ownerSuffix != suffix {
+		return newAPIError(http.StatusForbidden, ErrorCodeForbidden, fmt.Errorf("runner group %s does not belong to team %s", name, team))
+	}
+	return nil
+}
+
+// teamForGroupName inverts the naming template to recover the team slug and group suffix from the name of a runner
+// group. Names that match the template without a suffix are attributed to the default runner group of a team.
+func teamForGroupName(text, org, name string) (string, string, bool) {
+	for _, suffix := range []string{"", groupSuffixSentinel} {
+		pattern, err := executeGroupNameTemplate(text, org, groupNameSentinel, suffix)
+		if err != nil || suffix != "" && !strings.Contains(pattern, groupSuffixSentinel) {
+			continue
+		}
+		expr := regexp.QuoteMeta(pattern)
+		expr = strings.Replace(expr, groupNameSentinel, `(?P<team>`+teamSlugPattern+`)`, 1)
+		expr = strings.Replace(expr, groupSuffixSentinel, `(?P<suffix>`+groupSuffixPattern+`)`, 1)
+		re, err := regexp.Compile(`^` + expr + `$`)
+		if err != nil {
+			continue
+		}
+		match := re.FindStringSubmatch(name)
+		if match == nil {
+			continue
+		}
+		var team, groupSuffix string
+		for i, group := range re.SubexpNames() {
+			switch group {
+			case "team":
+				team = match[i]
+			case "suffix":
+				groupSuffix = match[i]
+			}
+		}
+		return team, groupSuffix, true
+	}
+	return "", "", false
+}
+
+// retrieveGroupSuffix parses the optional group parameter that selects one of the runner groups of a team, writing the
+// error response and returning false if it is not a valid group suffix
+func (m *Manager) retrieveGroupSuffix(c *gin.Context) (string, bool) {
+	suffix := c.Query("group")
+	if suffix == "" {
+		return "", true
+	}
+	if !isGroupSuffix(suffix) {
+		writeError(c, http.StatusBadRequest, ErrorCodeBadRequest, fmt.Sprintf("Invalid group %s, must only contain lowercase letters, digits and hyphens", suffix))
+		return "", false
+	}
+	if !supportsGroupSuffix(m.Config.Groups.NameTemplate) {
+		writeError(c, http.StatusBadRequest, ErrorCodeBadRequest, "Group names are not supported by the configured group name template")
+		return "", false
+	}
+	return suffix, true
+}
+
+// listTeamGroups lists the runner groups of the organization that are named after the team
+func (m *Manager) listTeamGroups(ctx context.Context, team string) ([]*github.RunnerGroup, error) {
+	groups, err := m.listRunnerGroups(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var teamGroups []*github.RunnerGroup
+	for _, group := range groups {
+		if owner, _, ok := m.Config.Groups.TeamFor(m.Config.Org, group.GetName()); ok && !group.GetDefault() && owner == team {
+			teamGroups = append(teamGroups, group)
+		}
+	}
+	return teamGroups, nil
+}
+
+// MigrateGroupNames renames the runner groups of every managed organization that are named after a team with the
+// previous naming template to the name given by the configured template. Runner groups that already follow the
+// configured template, are not named after an existing team, or whose new name is taken, are left untouched. No group
+// is renamed in dry-run mode.
+func (m *Manager) MigrateGroupNames(ctx context.Context, previous string, dryRun bool) ([]*GroupRename, error) {
+	if err := validateGroupNameTemplate(previous); err != nil {
+		return nil, err
+	}
+	var renames []*GroupRename
+	for _, org := range m.readinessOrganizations() {
+		orgRenames, err := org.migrateGroupNames(ctx, previous, dryRun)
+		if err != nil {
+			return renames, fmt.Errorf("unable to migrate runner groups of organization %s: %w", org.Config.Org, err)
+		}
+		renames = append(renames, orgRenames...)
+	}
+	return renames, nil
+}
+
+// migrateGroupNames renames the runner groups of the organization of the manager
+func (m *Manager) migrateGroupNames(ctx context.Context, previous string, dryRun bool) ([]*GroupRename, error) {
+	uuid := uuid.NewString()
+	m.Logger.WithField("uuid", uuid).Infof("Listing runner groups of organization %s to migrate", m.Config.Org)
+	groups, err := m.listRunnerGroups(ctx)
+	if err != nil {
+		return nil, err
+	}
+	existing := map[string]bool{}
+	for _, group := range groups {
+		existing[group.GetName()] = true
+	}
+	m.Logger.WithField("uuid", uuid).Debugf("Listed runner groups of organization %s to migrate", m.Config.Org)
+
+	var renames []*GroupRename
+	for _, group := range groups {
+		if _, _, migrated := m.Config.Groups.TeamFor(m.Config.Org, group.GetName()); migrated {
+			continue
+		}
+		team, suffix, ok := teamForGroupName(previous, m.Config.Org, group.GetName())
+		if group.GetDefault() || !ok {
+			continue
+		}
+		name, err := m.Config.Groups.GroupName(m.Config.Org, team, suffix)
+		if err != nil {
+			return renames, err
+		}
+
+		_, resp, err := m.TeamsClient.GetTeamBySlug(ctx, m.Config.Org, team)
+		if err != nil {
+			if resp != nil && resp.Response != nil && resp.StatusCode == http.StatusNotFound {
+				m.Logger.WithField("uuid", uuid).WithField("team", team).Debugf("Runner group %s is not named after a team, skipping", group.GetName())
+				continue
+			}
+			return renames, fmt.Errorf("unable to retrieve team %s: %w", team, err)
+		}
+
+		rename := &GroupRename{Org: m.Config.Org, Team: team, From: group.GetName(), To: name}
+		renames = append(renames, rename)
+		if existing[name] {
+			rename.Error = fmt.Sprintf("runner group %s already exists", name)
+			m.Logger.WithField("uuid", uuid).WithField("team", team).Warnf("Unable to rename runner group %s, %s", group.GetName(), rename.Error)
+			continue
+		}
+		if dryRun {
+			m.Logger.WithField("uuid", uuid).WithField("team", team).Infof("Dry run, would rename runner group %s to %s", group.GetName(), name)
+			continue
+		}
+
+		m.Logger.WithField("uuid", uuid).WithField("team", team).Infof("Renaming runner group %s to %s", group.GetName(), name)
+		_, resp, err = m.ActionsClient.UpdateOrganizationRunnerGroup(ctx, m.Config.Org, group.GetID(), github.UpdateRunnerGroupRequest{
+			Name: github.String(name),
+		})
+		code := http.StatusOK
+		if resp != nil && resp.Response != nil {
+			code = resp.StatusCode
+		} else if err != nil {
+			code = http.StatusInternalServerError
+		}
+		m.auditMigration(team, uuid, code)
+		if err != nil {
+			rename.Error = err.Error()
+			m.Logger.WithField("uuid", uuid).WithField("team", team).Errorf("Unable to rename runner group %s: %v", group.GetName(), err)
+			continue
+		}
+		existing[name] = true
+		m.Logger.WithField("uuid", uuid).WithField("team", team).Debugf("Renamed runner group %s to %s", group.GetName(), name)
+	}
+	return renames, nil
+}
+
+// auditMigration records the rename of a runner group by the migration when an audit log is configured
+func (m *Manager) auditMigration(team, uuid string, code int) {
+	if m.AuditLog == nil {
+		return
+	}
+	err := m.AuditLog.Write(&AuditEvent{
+		Time:      time.Now().UTC(),
+		RequestID: uuid,
+		Org:       m.Config.Org,
+		User:      migrationUser,
+		Team:      team,
+		Operation: migrationOperation,
+		Code:      code,
+	})
+	if err != nil {
+		m.Logger.WithField("uuid", uuid).WithField("team", team).Errorf("Unable to write audit event: %v", err)
+	}
+}
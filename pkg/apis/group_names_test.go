@@ -0,0 +1,326 @@
+/**
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package apis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/v41/github"
+	"github.com/lindluni/actions-runner-manager/pkg/apis/mocks"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGroups_NameTemplate(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name       string
+		template   string
+		maxPerTeam int
+		err        string
+		group      string
+		suffixed   string
+	}{
+		{name: "Default", maxPerTeam: 2, group: "fake-team", suffixed: "fake-team.gpu"},
+		{name: "Prefix", template: "arm-{{.Team}}", group: "arm-fake-team"},
+		{name: "Org", template: "{{.Org}}-{{.Team}}-runners", group: "fake-org-fake-team-runners"},
+		{name: "Suffix", template: "runners/{{.Team}}{{with .Suffix}}/{{.}}{{end}}", maxPerTeam: 3, group: "runners/fake-team", suffixed: "runners/fake-team/gpu"},
+		{name: "Invalid", template: "{{.Team", err: "invalid group name template {{.Team: template: group:1: unclosed action"},
+		{name: "Unknown", template: "{{.Runner}}-{{.Team}}", err: `invalid group name template {{.Runner}}-{{.Team}}: template: group:1:2: executing "group" at <.Runner>: can't evaluate field Runner in type *apis.groupNameData`},
+		{name: "Missing", template: "arm", err: "group name template arm must include {{.Team}} exactly once"},
+		{name: "Twice", template: "{{.Team}}-{{.Team}}", err: "group name template {{.Team}}-{{.Team}} must include {{.Team}} exactly once"},
+		{name: "SuffixTwice", template: "{{.Team}}{{.Suffix}}{{.Suffix}}", err: "group name template {{.Team}}{{.Suffix}}{{.Suffix}} must include {{.Suffix}} at most once"},
+		{name: "SuffixMissing", template: "arm-{{.Team}}", maxPerTeam: 2, err: "group name template arm-{{.Team}} must include {{.Suffix}} when maxPerTeam is greater than one"},
+		{name: "NegativeMax", maxPerTeam: -1, err: "maxPerTeam must not be negative"},
+		{name: "Unseparated", template: "{{.Team}}{{with .Suffix}}-{{.}}{{end}}", maxPerTeam: 2, err: "group name template {{.Team}}{{with .Suffix}}-{{.}}{{end}} must separate {{.Suffix}} from {{.Team}} with a character other than a lowercase letter, digit, underscore or hyphen"},
+		{name: "UnseparatedPrefix", template: "{{with .Suffix}}{{.}}_{{end}}{{.Team}}", maxPerTeam: 2, err: "group name template {{with .Suffix}}{{.}}_{{end}}{{.Team}} must separate {{.Suffix}} from {{.Team}} with a character other than a lowercase letter, digit, underscore or hyphen"},
+	}
+	for _, tc := range testCases {
+		groups := Groups{NameTemplate: tc.template, MaxPerTeam: tc.maxPerTeam}
+		err := groups.Validate()
+		if tc.err != "" {
+			require.EqualError(t, err, tc.err, tc.name)
+			continue
+		}
+		require.NoError(t, err, tc.name)
+
+		name, err := groups.GroupName("fake-org", "fake-team", "")
+		require.NoError(t, err, tc.name)
+		require.Equal(t, tc.group, name, tc.name)
+
+		team, suffix, ok := groups.TeamFor("fake-org", name)
+		require.True(t, ok, tc.name)
+		require.Equal(t, "fake-team", team, tc.name)
+		require.Empty(t, suffix, tc.name)
+
+		if tc.suffixed == "" {
+			continue
+		}
+		name, err = groups.GroupName("fake-org", "fake-team", "gpu")
+		require.NoError(t, err, tc.name)
+		require.Equal(t, tc.suffixed, name, tc.name)
+
+		team, suffix, ok = groups.TeamFor("fake-org", name)
+		require.True(t, ok, tc.name)
+		require.Equal(t, "fake-team", team, tc.name)
+		require.Equal(t, "gpu", suffix, tc.name)
+	}
+
+	groups := Groups{NameTemplate: "arm-{{.Team}}"}
+	for _, name := range []string{"fake-team", "arm-", "x86-fake-team", "arm-Fake Team"} {
+		_, _, ok := groups.TeamFor("fake-org", name)
+		require.False(t, ok, name)
+	}
+	require.Equal(t, 1, Groups{}.MaxGroupsPerTeam())
+	require.Equal(t, 3, Groups{MaxPerTeam: 3}.MaxGroupsPerTeam())
+}
+
+func TestGroupNameTemplateRoutes(t *testing.T) {
+	t.Parallel()
+
+	actionsClient := &mocks.ActionsClient{}
+	manager := newRunnerManager(actionsClient)
+	manager.Config.Groups.NameTemplate = "arm-{{.Team}}"
+	actionsClient.ListOrganizationRunnerGroupsReturns(&github.RunnerGroups{
+		RunnerGroups: []*github.RunnerGroup{
+			{ID: github.Int64(1), Name: github.String("fake-team")},
+			{ID: github.Int64(2), Name: github.String("arm-fake-team")},
+		},
+	}, &github.Response{}, nil)
+
+	id, err := manager.retrieveGroupID("fake-team", "", "fake-uuid")
+	require.NoError(t, err)
+	require.Equal(t, int64(2), *id)
+
+	actionsClient.CreateOrganizationRunnerGroupReturns(&github.RunnerGroup{ID: github.Int64(5)}, &github.Response{}, nil)
+	writer := httptest.NewRecorder()
+	request, err := http.NewRequest(http.MethodPost, "/api/v1/group-create?team=other-team", nil)
+	require.NoError(t, err)
+	request.Header.Set("Authorization", "test-token")
+	manager.Router.ServeHTTP(writer, request)
+	require.Equal(t, http.StatusOK, writer.Code, writer.Body.String())
+	_, org, createReq := actionsClient.CreateOrganizationRunnerGroupArgsForCall(0)
+	require.Equal(t, "fake-org", org)
+	require.Equal(t, "arm-other-team", createReq.GetName())
+}
+
+func TestMigrateGroupNames(t *testing.T) {
+	t.Parallel()
+
+	logger, _ := test.NewNullLogger()
+	testCases := []struct {
+		name    string
+		dryRun  bool
+		renamed int
+		renames []*GroupRename
+	}{
+		{
+			name:    "Rename",
+			renamed: 1,
+			renames: []*GroupRename{
+				{Org: "fake-org", Team: "fake-team", From: "fake-team", To: "arm-fake-team"},
+				{Org: "fake-org", Team: "taken-team", From: "taken-team", To: "arm-taken-team", Error: "runner group arm-taken-team already exists"},
+			},
+		},
+		{
+			name:   "DryRun",
+			dryRun: true,
+			renames: []*GroupRename{
+				{Org: "fake-org", Team: "fake-team", From: "fake-team", To: "arm-fake-team"},
+				{Org: "fake-org", Team: "taken-team", From: "taken-team", To: "arm-taken-team", Error: "runner group arm-taken-team already exists"},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		actionsClient := &mocks.ActionsClient{}
+		actionsClient.ListOrganizationRunnerGroupsReturns(&github.RunnerGroups{
+			RunnerGroups: []*github.RunnerGroup{
+				{ID: github.Int64(1), Name: github.String("Default"), Default: github.Bool(true)},
+				{ID: github.Int64(2), Name: github.String("fake-team")},
+				{ID: github.Int64(3), Name: github.String("arm-done-team")},
+				{ID: github.Int64(4), Name: github.String("shared-runners")},
+				{ID: github.Int64(5), Name: github.String("taken-team")},
+				{ID: github.Int64(6), Name: github.String("arm-taken-team")},
+			},
+		}, &github.Response{}, nil)
+		actionsClient.UpdateOrganizationRunnerGroupReturns(&github.RunnerGroup{}, &github.Response{Response: &http.Response{StatusCode: http.StatusOK}}, nil)
+		teamsClient := &mocks.TeamsClient{}
+		teamsClient.GetTeamBySlugCalls(func(_ context.Context, _, slug string) (*github.Team, *github.Response, error) {
+			if slug == "shared-runners" {
+				return nil, &github.Response{Response: &http.Response{StatusCode: http.StatusNotFound}}, errors.New("not found")
+			}
+			return &github.Team{Slug: github.String(slug)}, &github.Response{}, nil
+		})
+		sink, err := NewFileAuditSink(filepath.Join(t.TempDir(), "audit.jsonl"))
+		require.NoError(t, err, tc.name)
+
+		manager := &Manager{
+			ActionsClient: actionsClient,
+			AuditLog:      sink,
+			Config:        &Config{Org: "fake-org", Groups: Groups{NameTemplate: "arm-{{.Team}}"}},
+			Logger:        logger,
+			TeamsClient:   teamsClient,
+		}
+		renames, err := manager.MigrateGroupNames(context.Background(), DefaultGroupNameTemplate, tc.dryRun)
+		require.NoError(t, err, tc.name)
+		require.Equal(t, tc.renames, renames, tc.name)
+		require.Equal(t, tc.renamed, actionsClient.UpdateOrganizationRunnerGroupCallCount(), tc.name)
+
+		events, err := sink.Query(&AuditFilter{})
+		require.NoError(t, err, tc.name)
+		require.NoError(t, sink.Close(), tc.name)
+		require.Len(t, events, tc.renamed, tc.name)
+		if tc.renamed == 0 {
+			continue
+		}
+		_, org, groupID, request := actionsClient.UpdateOrganizationRunnerGroupArgsForCall(0)
+		require.Equal(t, "fake-org", org, tc.name)
+		require.Equal(t, int64(2), groupID, tc.name)
+		require.Equal(t, "arm-fake-team", request.GetName(), tc.name)
+		require.Equal(t, migrationUser, events[0].User, tc.name)
+		require.Equal(t, migrationOperation, events[0].Operation, tc.name)
+		require.Equal(t, "fake-team", events[0].Team, tc.name)
+	}
+
+	manager := &Manager{Config: &Config{Org: "fake-org"}, Logger: logger}
+	_, err := manager.MigrateGroupNames(context.Background(), "arm", false)
+	require.EqualError(t, err, "group name template arm must include {{.Team}} exactly once")
+}
+
+func TestGroupOwner(t *testing.T) {
+	t.Parallel()
+
+	// A template that predates the separator validation names the gpu runner group of team data like the default runner
+	// group of team data-gpu
+	actionsClient := &mocks.ActionsClient{}
+	manager := newRunnerManager(actionsClient)
+	manager.Config.Groups.NameTemplate = "{{.Team}}{{with .Suffix}}-{{.}}{{end}}"
+	actionsClient.ListOrganizationRunnerGroupsReturns(&github.RunnerGroups{
+		RunnerGroups: []*github.RunnerGroup{{ID: github.Int64(7), Name: github.String("data-gpu")}},
+	}, &github.Response{}, nil)
+
+	id, err := manager.retrieveGroupID("data", "gpu", "fake-uuid")
+	require.EqualError(t, err, "runner group data-gpu does not belong to team data")
+	require.Nil(t, id)
+	id, err = manager.retrieveGroupID("data-gpu", "", "fake-uuid")
+	require.NoError(t, err)
+	require.Equal(t, int64(7), *id)
+
+	for _, tc := range []struct {
+		method string
+		path   string
+	}{
+		{method: http.MethodPost, path: "/api/v1/group-create?team=data&group=gpu"},
+		{method: http.MethodDelete, path: "/api/v1/group-delete?team=data&group=gpu"},
+	} {
+		writer := httptest.NewRecorder()
+		request, err := http.NewRequest(tc.method, tc.path, nil)
+		require.NoError(t, err, tc.path)
+		request.Header.Set("Authorization", "test-token")
+		manager.Router.ServeHTTP(writer, request)
+		require.Equal(t, http.StatusForbidden, writer.Code, tc.path)
+	}
+	require.Equal(t, 0, actionsClient.CreateOrganizationRunnerGroupCallCount())
+	require.Equal(t, 0, actionsClient.DeleteOrganizationRunnerGroupCallCount())
+}
+
+func TestSecondaryGroupRoutes(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		method   string
+		path     string
+		response string
+	}{
+		{method: http.MethodPost, path: "/api/v1/runner-register?team=fake-team&group=gpu&name=fake-runner"},
+		{method: http.MethodGet, path: "/api/v1/runner-list?team=fake-team&group=gpu"},
+		{method: http.MethodDelete, path: "/api/v1/runner-delete?team=fake-team&group=gpu&runner=fake-runner"},
+		{method: http.MethodGet, path: "/api/v1/labels-list?team=fake-team&group=gpu&runner=fake-runner"},
+		{method: http.MethodPatch, path: "/api/v1/labels-set?team=fake-team&group=gpu&runner=fake-runner&labels=cuda"},
+		{method: http.MethodGet, path: "/api/v1/workflows-list?team=fake-team&group=gpu"},
+		{method: http.MethodPatch, path: "/api/v1/workflows-set?team=fake-team&group=gpu&workflows="},
+		{method: http.MethodGet, path: "/api/v1/janitor-report?team=fake-team&group=gpu"},
+		{method: http.MethodPatch, path: "/api/v1/group-update?team=fake-team&group=gpu&visibility=selected", response: "Runner group updated successfully: fake-team.gpu"},
+		{method: http.MethodDelete, path: "/api/v1/group-delete?team=fake-team&group=gpu", response: "Runner group deleted successfully: fake-team.gpu"},
+		{method: http.MethodPost, path: "/api/v2/teams/fake-team/runner-group/runners?group=gpu"},
+		{method: http.MethodGet, path: "/api/v2/teams/fake-team/runner-group/runners?group=gpu"},
+		{method: http.MethodDelete, path: "/api/v2/teams/fake-team/runner-group/runners/fake-runner?group=gpu"},
+	}
+	for _, tc := range tests {
+		actionsClient := &mocks.ActionsClient{}
+		manager := newRunnerManager(actionsClient)
+		actionsClient.ListOrganizationRunnerGroupsReturns(&github.RunnerGroups{
+			RunnerGroups: []*github.RunnerGroup{
+				{ID: github.Int64(2), Name: github.String("fake-team")},
+				{ID: github.Int64(5), Name: github.String("fake-team.gpu")},
+			},
+		}, &github.Response{}, nil)
+		actionsClient.GetOrganizationRunnerReturns(&github.Runner{ID: github.Int64(3), Name: github.String("fake-runner")}, &github.Response{}, nil)
+		actionsClient.RemoveOrganizationRunnerReturns(&github.Response{}, nil)
+		actionsClient.UpdateOrganizationRunnerGroupReturns(&github.RunnerGroup{}, &github.Response{}, nil)
+		actionsClient.DeleteOrganizationRunnerGroupReturns(&github.Response{}, nil)
+		restClient := &mocks.RestClient{}
+		restClient.NewRequestStub = func(method, urlStr string, body interface{}) (*http.Request, error) {
+			return github.NewClient(nil).NewRequest(method, urlStr, body)
+		}
+		restClient.DoReturns(&github.Response{Response: &http.Response{StatusCode: http.StatusOK}}, nil)
+		manager.RestClient = restClient
+
+		var body io.Reader
+		if strings.HasPrefix(tc.path, "/api/v2") && tc.method == http.MethodPost {
+			body = strings.NewReader(`{"name": "fake-runner"}`)
+		}
+		writer := httptest.NewRecorder()
+		request, err := http.NewRequest(tc.method, tc.path, body)
+		require.NoError(t, err, tc.path)
+		request.Header.Set("Authorization", "test-token")
+		request.Header.Set("Content-Type", "application/json")
+		manager.Router.ServeHTTP(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code, tc.path+": "+writer.Body.String())
+		if tc.response != "" {
+			response := &JSONResultSuccess{}
+			require.NoError(t, json.Unmarshal(writer.Body.Bytes(), response), tc.path)
+			require.Equal(t, tc.response, response.Response, tc.path)
+		}
+
+		var groupIDs []int64
+		for i := 0; i < actionsClient.ListRunnerGroupRunnersCallCount(); i++ {
+			_, _, groupID, _ := actionsClient.ListRunnerGroupRunnersArgsForCall(i)
+			groupIDs = append(groupIDs, groupID)
+		}
+		if actionsClient.UpdateOrganizationRunnerGroupCallCount() > 0 {
+			_, _, groupID, _ := actionsClient.UpdateOrganizationRunnerGroupArgsForCall(0)
+			groupIDs = append(groupIDs, groupID)
+		}
+		if actionsClient.DeleteOrganizationRunnerGroupCallCount() > 0 {
+			_, _, groupID := actionsClient.DeleteOrganizationRunnerGroupArgsForCall(0)
+			groupIDs = append(groupIDs, groupID)
+		}
+		for i := 0; i < restClient.NewRequestCallCount(); i++ {
+			_, url, requestBody := restClient.NewRequestArgsForCall(i)
+			if jit, ok := requestBody.(*JITRunnerConfigRequest); ok {
+				groupIDs = append(groupIDs, jit.RunnerGroupID)
+			} else if strings.Contains(url, "runner-groups/") {
+				id, err := strconv.ParseInt(url[strings.LastIndex(url, "/")+1:], 10, 64)
+				require.NoError(t, err, tc.path)
+				groupIDs = append(groupIDs, id)
+			}
+		}
+		require.NotEmpty(t, groupIDs, tc.path)
+		for _, groupID := range groupIDs {
+			require.Equal(t, int64(5), groupID, tc.path)
+		}
+	}
+}
@@ -0,0 +1,230 @@
+/**
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package apis
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/go-github/v41/github"
+)
+
+const (
+	// VisibilitySelected grants the runner group to the repositories assigned to it
+	VisibilitySelected = "selected"
+	// VisibilityPrivate grants the runner group to every private repository of the organization
+	VisibilityPrivate = "private"
+	// VisibilityAll grants the runner group to every repository of the organization
+	VisibilityAll = "all"
+)
+
+var visibilities = map[string]bool{
+	VisibilitySelected: true,
+	VisibilityPrivate:  true,
+	VisibilityAll:      true,
+}
+
+// Groups bounds the settings maintainers may choose when creating or updating their runner group. By default, runner
+// groups are only granted to the repositories assigned to them and cannot be used by public repositories.
+// RequireWorkflowRestriction forces every runner group to be restricted to at least one workflow, and when
+// AllowedWorkflows is set, runner groups can only be restricted to workflows matching one of its glob patterns.
+// NameTemplate is the text/template the name of the runner group of a team is executed from, with the Org, Team and
+// Suffix fields, and defaults to DefaultGroupNameTemplate. MaxPerTeam limits the number of runner groups a team can
+// create, told apart by their suffix, and defaults to one.
+type Groups struct {
+	NameTemplate               string   `yaml:"nameTemplate"`
+	MaxPerTeam                 int      `yaml:"maxPerTeam"`
+	AllowedVisibilities        []string `yaml:"allowedVisibilities"`
+	AllowPublicRepositories    bool     `yaml:"allowPublicRepositories"`
+	RequireWorkflowRestriction bool     `yaml:"requireWorkflowRestriction"`
+	AllowedWorkflows           []string `yaml:"allowedWorkflows"`
+}
+
+// MaxGroupsPerTeam returns the number of runner groups a team can create
+func (g Groups) MaxGroupsPerTeam() int {
+	if g.MaxPerTeam <= 0 {
+		return 1
+	}
+	return g.MaxPerTeam
+}
+
+// Validate verifies the naming template and group limit, that every allowed visibility is known and every allowed
+// workflow is a valid glob pattern
+func (g Groups) Validate() error {
+	if err := validateGroupNameTemplate(g.NameTemplate); err != nil {
+		return err
+	}
+	if g.MaxPerTeam < 0 {
+		return fmt.Errorf("maxPerTeam must not be negative")
+	}
+	if g.MaxPerTeam > 1 && !supportsGroupSuffix(g.NameTemplate) {
+		return fmt.Errorf("group name template %s must include {{.Suffix}} when maxPerTeam is greater than one", g.NameTemplate)
+	}
+	for _, visibility := range g.AllowedVisibilities {
+		if !visibilities[visibility] {
+			return fmt.Errorf("unknown visibility %s", visibility)
+		}
+	}
+	for _, pattern := range g.AllowedWorkflows {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid allowed workflow pattern %s: %w", pattern, err)
+		}
+	}
+	return nil
+}
+
+// AllowsWorkflows verifies the runner group may be restricted to the workflows, where an empty list lifts the
+// restriction
+func (g Groups) AllowsWorkflows(workflows []string) error {
+	if len(workflows) == 0 && g.RequireWorkflowRestriction {
+		return fmt.Errorf("runner groups must be restricted to at least one workflow")
+	}
+	if len(g.AllowedWorkflows) == 0 {
+		return nil
+	}
+	for _, workflow := range workflows {
+		allowed := false
+		for _, pattern := range g.AllowedWorkflows {
+			if matched, _ := path.Match(pattern, workflow); matched {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return fmt.Errorf("workflow %s is not allowed", workflow)
+		}
+	}
+	return nil
+}
+
+// AllowsVisibility reports whether maintainers may choose the visibility, which is only selected when unconfigured
+func (g Groups) AllowsVisibility(visibility string) bool {
+	if len(g.AllowedVisibilities) == 0 {
+		return visibility == VisibilitySelected
+	}
+	for _, allowed := range g.AllowedVisibilities {
+		if allowed == visibility {
+			return true
+		}
+	}
+	return false
+}
+
+// groupSettings holds the optional settings of a runner group passed to the group-create and group-update APIs. A nil
+// field leaves the setting unchanged.
+type groupSettings struct {
+	Visibility               *string
+	AllowsPublicRepositories *bool
+	// Workflows restricts the runner group to the workflows when not nil, an empty list lifts the restriction
+	Workflows []string
+}
+
+// runnerGroupWorkflows restricts a runner group to a list of workflows. The settings are not yet exposed by the
+// go-github ActionsService, so they are sent through the underlying REST client.
+type runnerGroupWorkflows struct {
+	RestrictedToWorkflows bool     `json:"restricted_to_workflows"`
+	SelectedWorkflows     []string `json:"selected_workflows"`
+}
+
+// retrieveGroupSettings parses the visibility, allowsPublicRepositories and workflows parameters and verifies them
+// against the configured limits, writing the error response and returning nil if a parameter is invalid
+func (m *Manager) retrieveGroupSettings(c *gin.Context) *groupSettings {
+	settings := &groupSettings{}
+	if visibility, ok := c.GetQuery("visibility"); ok {
+		if !visibilities[visibility] {
+			writeError(c, http.StatusBadRequest, ErrorCodeBadRequest, fmt.Sprintf("Invalid visibility %s, must be one of selected, private or all", visibility))
+			return nil
+		}
+		if !m.Config.Groups.AllowsVisibility(visibility) {
+			writeError(c, http.StatusForbidden, ErrorCodeForbidden, fmt.Sprintf("Visibility %s is not allowed", visibility))
+			return nil
+		}
+		settings.Visibility = github.String(visibility)
+	}
+	if param, ok := c.GetQuery("allowsPublicRepositories"); ok {
+		allowsPublic, err := strconv.ParseBool(param)
+		if err != nil {
+			writeError(c, http.StatusBadRequest, ErrorCodeBadRequest, fmt.Sprintf("Invalid allowsPublicRepositories %s, must be true or false", param))
+			return nil
+		}
+		if allowsPublic && !m.Config.Groups.AllowPublicRepositories {
+			writeError(c, http.StatusForbidden, ErrorCodeForbidden, "Public repositories are not allowed")
+			return nil
+		}
+		settings.AllowsPublicRepositories = github.Bool(allowsPublic)
+	}
+	if param, ok := c.GetQuery("workflows"); ok {
+		settings.Workflows = m.retrieveWorkflows(c, param)
+		if settings.Workflows == nil {
+			return nil
+		}
+	}
+	return settings
+}
+
+// retrieveWorkflows parses a comma-seperated list of workflows and verifies it against the configured policy, writing
+// the error response and returning nil if a workflow is malformed or not allowed. An empty list lifts the restriction.
+func (m *Manager) retrieveWorkflows(c *gin.Context, param string) []string {
+	workflows := []string{}
+	for _, workflow := range strings.Split(param, ",") {
+		workflow = strings.TrimSpace(workflow)
+		if workflow == "" {
+			continue
+		}
+		if !isWorkflowRef(workflow) {
+			writeError(c, http.StatusBadRequest, ErrorCodeBadRequest, fmt.Sprintf("Invalid workflow %s, must be <owner>/<repo>/<path>@<ref>", workflow))
+			return nil
+		}
+		workflows = append(workflows, workflow)
+	}
+	if err := m.Config.Groups.AllowsWorkflows(workflows); err != nil {
+		writeError(c, http.StatusForbidden, ErrorCodeForbidden, fmt.Sprintf("Workflows not allowed by policy: %v", err))
+		return nil
+	}
+	return workflows
+}
+
+// isWorkflowRef reports whether the workflow names a workflow file in a repository at a ref
+func isWorkflowRef(workflow string) bool {
+	at := strings.LastIndex(workflow, "@")
+	if at <= 0 || at == len(workflow)-1 {
+		return false
+	}
+	parts := strings.SplitN(workflow[:at], "/", 3)
+	return len(parts) == 3 && parts[0] != "" && parts[1] != "" && parts[2] != ""
+}
+
+// setGroupWorkflows restricts the runner group to the workflows, or lifts the restriction when workflows is empty
+func (m *Manager) setGroupWorkflows(ctx context.Context, groupID int64, workflows []string) (*github.Response, error) {
+	u := fmt.Sprintf("orgs/%v/actions/runner-groups/%v", m.Config.Org, groupID)
+	req, err := m.RestClient.NewRequest(http.MethodPatch, u, &runnerGroupWorkflows{
+		RestrictedToWorkflows: len(workflows) > 0,
+		SelectedWorkflows:     workflows,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return m.RestClient.Do(ctx, req, nil)
+}
+
+// getGroupWorkflows retrieves the workflows the runner group is restricted to
+func (m *Manager) getGroupWorkflows(ctx context.Context, groupID int64) (*runnerGroupWorkflows, *github.Response, error) {
+	u := fmt.Sprintf("orgs/%v/actions/runner-groups/%v", m.Config.Org, groupID)
+	req, err := m.RestClient.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	workflows := &runnerGroupWorkflows{}
+	resp, err := m.RestClient.Do(ctx, req, workflows)
+	if err != nil {
+		return nil, resp, err
+	}
+	return workflows, resp, nil
+}
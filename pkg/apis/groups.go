@@ -8,6 +8,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"sort"
 
 	"github.com/gin-contrib/requestid"
 	"github.com/gin-gonic/gin"
@@ -19,239 +20,358 @@ type listResponse struct {
 	Runners []string `json:"runners"`
 }
 
+// teamGroup is a runner group of a team, where Group is the suffix that tells it apart from the other runner groups of
+// the team and is empty for its default runner group
+type teamGroup struct {
+	ID                       int64  `json:"id"`
+	Name                     string `json:"name"`
+	Group                    string `json:"group"`
+	Visibility               string `json:"visibility"`
+	AllowsPublicRepositories bool   `json:"allowsPublicRepositories"`
+}
+
 type JSONResultSuccess struct {
 	Code     int         `json:"Code" `
 	Response interface{} `json:"Response"`
 }
 
-type JSONResultError struct {
-	Code  int    `json:"Code" `
-	Error string `json:"Error"`
-}
-
 // DoGroupCreate Create a new GitHub Action organization Runner Group
 // @Summary      Create a new GitHub Action organization Runner Group
 // @Description  Creates a new GitHub Action organization runner group named with the team slug
 // @Tags         Groups
 // @Produce      json
-// @Param        team           query     string  true  "Canonical **slug** of the GitHub team"
-// @Param        Authorization  header    string  true  "Authorization token"
-// @Success      200            {object}  JSONResultSuccess{Code=int,Response=string}
-// @Router       /group-create [post]
+// @Param        team                      query     string    true   "Canonical **slug** of the GitHub team"
+// @Param        group                     query     string    false  "Suffix of the runner group of the team, selects its default runner group when unset"
+// @Param        dry_run                   query     bool      false  "Validate the request and return the change it would make without making it"
+// @Param        visibility                query     string    false  "Visibility of the runner group, defaults to selected"  Enums(selected, private, all)
+// @Param        allowsPublicRepositories  query     bool      false  "Allow public repositories to use the runner group, defaults to false"
+// @Param        workflows                 query     []string  false  "Comma-seperated list of workflows the runner group is restricted to"
+// @Param        Authorization             header    string    true   "Authorization token"
+// @Success      200                       {object}  JSONResultSuccess{Code=int,Response=string}
+// @Router       /v1/group-create [post]
 // @Security     ApiKeyAuth
 func (m *Manager) DoGroupCreate(c *gin.Context) {
 	uuid := requestid.Get(c)
+	team := c.GetString(teamKey)
 
-	m.Logger.Info("Retrieving team parameter")
-	team := c.Query("team")
-	if team == "" {
-		c.JSON(http.StatusBadRequest, &JSONResultError{
-			Code:  http.StatusBadRequest,
-			Error: "Missing required parameter: team",
-		})
+	m.Logger.WithField("uuid", uuid).WithField("team", team).Info("Retrieving group and dry_run parameters")
+	suffix, ok := m.retrieveGroupSuffix(c)
+	if !ok {
+		return
+	}
+	dryRun, ok := retrieveDryRun(c)
+	if !ok {
 		return
 	}
-	m.Logger.WithField("uuid", uuid).WithField("team", team).Debug("Retrieved team parameter")
+	m.Logger.WithField("uuid", uuid).WithField("team", team).Debug("Retrieved group and dry_run parameters")
 
-	m.Logger.WithField("uuid", uuid).WithField("team", team).Info("Retrieving Authorization header")
-	fmt.Println(c)
-	token := c.GetHeader("Authorization")
-	if token == "" {
-		c.JSON(http.StatusForbidden, &JSONResultError{
-			Code:  http.StatusForbidden,
-			Error: "Missing Authorization header",
-		})
+	m.Logger.WithField("uuid", uuid).WithField("team", team).Info("Retrieving runner group settings")
+	settings := m.retrieveGroupSettings(c)
+	if settings == nil {
 		return
 	}
-	m.Logger.WithField("uuid", uuid).WithField("team", team).Debug("Retrieved Authorization header")
+	if settings.Visibility == nil {
+		settings.Visibility = github.String(VisibilitySelected)
+	}
+	if settings.AllowsPublicRepositories == nil {
+		settings.AllowsPublicRepositories = github.Bool(false)
+	}
+	if settings.Workflows == nil {
+		if err := m.Config.Groups.AllowsWorkflows(nil); err != nil {
+			writeError(c, http.StatusForbidden, ErrorCodeForbidden, fmt.Sprintf("Workflows not allowed by policy: %v", err))
+			return
+		}
+	}
+	m.Logger.WithField("uuid", uuid).WithField("team", team).Debug("Retrieved runner group settings")
 
-	m.Logger.WithField("uuid", uuid).WithField("team", team).Info("Verifying maintainership")
-	isMaintainer, err := m.verifyMaintainership(token, team, uuid)
+	name, err := m.Config.Groups.GroupName(m.Config.Org, team, suffix)
 	if err != nil {
-		c.JSON(http.StatusForbidden, &JSONResultError{
-			Code:  http.StatusForbidden,
-			Error: fmt.Sprintf("Unable to validate user is a team maintainer: %v", err),
-		})
+		writeError(c, http.StatusInternalServerError, ErrorCodeInternal, fmt.Sprintf("Unable to name runner group: %v", err))
 		return
 	}
-	if !isMaintainer {
-		c.JSON(http.StatusUnauthorized, &JSONResultError{
-			Code:  http.StatusUnauthorized,
-			Error: "User is not a maintainer of the team",
-		})
+	if err := m.Config.Groups.checkGroupOwner(m.Config.Org, name, team, suffix); err != nil {
+		writeAPIError(c, err, fmt.Sprintf("Unable to name runner group: %v", err))
 		return
 	}
-	m.Logger.WithField("uuid", uuid).WithField("team", team).Debug("Verified maintainership")
 
 	ctx := context.Background()
-	m.Logger.WithField("uuid", uuid).WithField("team", team).Info("Creating runner group")
+	m.Logger.WithField("uuid", uuid).WithField("team", team).Info("Counting runner groups of team")
+	groups, err := m.listTeamGroups(ctx, team)
+	if err != nil {
+		writeAPIError(c, err, fmt.Sprintf("Unable to list runner groups: %v", err))
+		return
+	}
+	if limit := m.Config.Groups.MaxGroupsPerTeam(); len(groups) >= limit && !containsGroup(groups, name) {
+		writeError(c, http.StatusForbidden, ErrorCodeForbidden, fmt.Sprintf("Team %s already has the maximum of %d runner groups", team, limit))
+		return
+	}
+	m.Logger.WithField("uuid", uuid).WithField("team", team).Debug("Counted runner groups of team")
+
+	if dryRun {
+		if containsGroup(groups, name) {
+			writeError(c, http.StatusConflict, ErrorCodeConflict, fmt.Sprintf("Runner group already exists: %s", name))
+			return
+		}
+		m.Logger.WithField("uuid", uuid).WithField("team", team).Infof("Dry run, would create runner group %s", name)
+		writeDryRun(c, http.StatusOK, &dryRunDiff{GroupCreated: name})
+		return
+	}
+
+	m.Logger.WithField("uuid", uuid).WithField("team", team).Infof("Creating runner group %s", name)
 	group, resp, err := m.ActionsClient.CreateOrganizationRunnerGroup(ctx, m.Config.Org, github.CreateRunnerGroupRequest{
-		Name:                     github.String(team),
-		Visibility:               github.String("selected"),
-		AllowsPublicRepositories: github.Bool(false),
+		Name:                     github.String(name),
+		Visibility:               settings.Visibility,
+		AllowsPublicRepositories: settings.AllowsPublicRepositories,
 	})
 	if err != nil {
-		if resp != nil && resp.StatusCode == http.StatusConflict {
-			c.JSON(http.StatusConflict, &JSONResultError{
-				Code:  http.StatusConflict,
-				Error: fmt.Sprintf("Runner group already exists: %s", team),
-			})
+		apiErr := gitHubError(resp, err)
+		if apiErr.status == http.StatusConflict {
+			writeAPIError(c, apiErr, fmt.Sprintf("Runner group already exists: %s", name))
 			return
 		}
-		c.JSON(http.StatusInternalServerError, &JSONResultError{
-			Code:  http.StatusInternalServerError,
-			Error: fmt.Sprintf("Unable to create runner group: %v", err),
-		})
+		writeAPIError(c, apiErr, fmt.Sprintf("Unable to create runner group: %v", err))
 		return
 	}
 	m.Logger.WithField("uuid", uuid).WithField("team", team).Debug("Created runner group")
 
+	if len(settings.Workflows) > 0 {
+		m.Logger.WithField("uuid", uuid).WithField("team", team).Infof("Restricting runner group to workflows %v", settings.Workflows)
+		resp, err := m.setGroupWorkflows(ctx, group.GetID(), settings.Workflows)
+		if err != nil {
+			// The runner group would otherwise be left usable by every workflow, so it is removed again
+			m.Logger.WithField("uuid", uuid).WithField("team", team).Warnf("Unable to restrict workflows, deleting runner group %s: %v", name, err)
+			if _, deleteErr := m.ActionsClient.DeleteOrganizationRunnerGroup(ctx, m.Config.Org, group.GetID()); deleteErr != nil {
+				m.Logger.WithField("uuid", uuid).WithField("team", team).Errorf("Unable to delete unrestricted runner group %s: %v", name, deleteErr)
+				writeAPIError(c, gitHubError(resp, err), fmt.Sprintf("Runner group created but unable to restrict workflows: %v, and unable to delete it: %v", err, deleteErr))
+				return
+			}
+			writeAPIError(c, gitHubError(resp, err), fmt.Sprintf("Unable to restrict workflows, runner group was not created: %v", err))
+			return
+		}
+		m.Logger.WithField("uuid", uuid).WithField("team", team).Debug("Restricted runner group to workflows")
+	}
+
 	c.JSON(http.StatusOK, &JSONResultSuccess{
 		Code:     http.StatusOK,
 		Response: fmt.Sprintf("Runner group created successfully: %s", group.GetName()),
 	})
 }
 
-// DoGroupDelete Deletes an existing GitHub Action organization Runner Group
-// @Summary      Deletes an existing GitHub Action organization Runner Group
-// @Description  Deletes an existing GitHub Action organization runner group named with the team slug
+// DoGroupUpdate Update the settings of an existing GitHub Action organization Runner Group
+// @Summary      Update the settings of an existing GitHub Action organization Runner Group
+// @Description  Updates the visibility, public repository access and workflow restrictions of the runner group named with the team slug. Settings that are not passed are left unchanged, and an empty workflows parameter lifts the workflow restriction.
 // @Tags         Groups
 // @Produce      json
-// @Param        team  query     string  true  "Canonical **slug** of the GitHub team"
-// @Success      200   {object}  JSONResultSuccess{Code=int,Response=string}
-// @Router       /group-delete [delete]
+// @Param        team                      query     string    true   "Canonical **slug** of the GitHub team"
+// @Param        group                     query     string    false  "Suffix of the runner group of the team, selects its default runner group when unset"
+// @Param        visibility                query     string    false  "Visibility of the runner group"  Enums(selected, private, all)
+// @Param        allowsPublicRepositories  query     bool      false  "Allow public repositories to use the runner group"
+// @Param        workflows                 query     []string  false  "Comma-seperated list of workflows the runner group is restricted to"
+// @Success      200                       {object}  JSONResultSuccess{Code=int,Response=string}
+// @Router       /v1/group-update [patch]
 // @Security     ApiKeyAuth
-func (m *Manager) DoGroupDelete(c *gin.Context) {
+func (m *Manager) DoGroupUpdate(c *gin.Context) {
 	uuid := requestid.Get(c)
+	team := c.GetString(teamKey)
 
-	m.Logger.Info("Retrieving team parameter")
-	team := c.Query("team")
-	if team == "" {
-		c.JSON(http.StatusBadRequest, &JSONResultError{
-			Code:  http.StatusBadRequest,
-			Error: "Missing required parameter: team",
-		})
+	m.Logger.WithField("uuid", uuid).WithField("team", team).Info("Retrieving group parameter")
+	suffix, ok := m.retrieveGroupSuffix(c)
+	if !ok {
 		return
 	}
-	m.Logger.WithField("uuid", uuid).WithField("team", team).Debug("Retrieved team parameter")
+	m.Logger.WithField("uuid", uuid).WithField("team", team).Debug("Retrieved group parameter")
 
-	m.Logger.WithField("uuid", uuid).WithField("team", team).Info("Retrieving Authorization header")
-	token := c.GetHeader("Authorization")
-	if token == "" {
-		c.JSON(http.StatusForbidden, &JSONResultError{
-			Code:  http.StatusForbidden,
-			Error: "Missing Authorization header",
-		})
+	m.Logger.WithField("uuid", uuid).WithField("team", team).Info("Retrieving runner group settings")
+	settings := m.retrieveGroupSettings(c)
+	if settings == nil {
 		return
 	}
-	m.Logger.WithField("uuid", uuid).WithField("team", team).Debug("Retrieved Authorization header")
+	if settings.Visibility == nil && settings.AllowsPublicRepositories == nil && settings.Workflows == nil {
+		writeError(c, http.StatusBadRequest, ErrorCodeBadRequest, "Missing required parameter: at least one of visibility, allowsPublicRepositories or workflows")
+		return
+	}
+	m.Logger.WithField("uuid", uuid).WithField("team", team).Debug("Retrieved runner group settings")
 
-	m.Logger.WithField("uuid", uuid).WithField("team", team).Info("Verifying maintainership")
-	isMaintainer, err := m.verifyMaintainership(token, team, uuid)
+	m.Logger.WithField("uuid", uuid).WithField("team", team).Info("Retrieving runner group ID")
+	groupID, err := m.retrieveGroupID(team, suffix, uuid)
 	if err != nil {
-		c.JSON(http.StatusForbidden, &JSONResultError{
-			Code:  http.StatusForbidden,
-			Error: fmt.Sprintf("Unable to validate user is a team maintainer: %v", err),
-		})
+		writeAPIError(c, err, fmt.Sprintf("Unable to retrieve group ID: %v", err))
 		return
 	}
-	if !isMaintainer {
-		c.JSON(http.StatusUnauthorized, &JSONResultError{
-			Code:  http.StatusUnauthorized,
-			Error: "User is not a maintainer of the team",
+	m.Logger.WithField("uuid", uuid).WithField("team", team).Debug("Retrieved runner group ID")
+	name, err := m.Config.Groups.GroupName(m.Config.Org, team, suffix)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, ErrorCodeInternal, fmt.Sprintf("Unable to name runner group: %v", err))
+		return
+	}
+
+	ctx := context.Background()
+	if settings.Visibility != nil || settings.AllowsPublicRepositories != nil {
+		m.Logger.WithField("uuid", uuid).WithField("team", team).Info("Updating runner group")
+		_, resp, err := m.ActionsClient.UpdateOrganizationRunnerGroup(ctx, m.Config.Org, *groupID, github.UpdateRunnerGroupRequest{
+			Visibility:               settings.Visibility,
+			AllowsPublicRepositories: settings.AllowsPublicRepositories,
 		})
+		if err != nil {
+			writeAPIError(c, gitHubError(resp, err), fmt.Sprintf("Unable to update runner group: %v", err))
+			return
+		}
+		m.Logger.WithField("uuid", uuid).WithField("team", team).Debug("Updated runner group")
+	}
+
+	if settings.Workflows != nil {
+		m.Logger.WithField("uuid", uuid).WithField("team", team).Infof("Restricting runner group to workflows %v", settings.Workflows)
+		resp, err := m.setGroupWorkflows(ctx, *groupID, settings.Workflows)
+		if err != nil {
+			writeAPIError(c, gitHubError(resp, err), fmt.Sprintf("Unable to restrict runner group workflows: %v", err))
+			return
+		}
+		m.Logger.WithField("uuid", uuid).WithField("team", team).Debug("Restricted runner group to workflows")
+	}
+
+	c.JSON(http.StatusOK, &JSONResultSuccess{
+		Code:     http.StatusOK,
+		Response: fmt.Sprintf("Runner group updated successfully: %s", name),
+	})
+}
+
+// DoGroupDelete Deletes an existing GitHub Action organization Runner Group
+// @Summary      Deletes an existing GitHub Action organization Runner Group
+// @Description  Deletes an existing GitHub Action organization runner group named with the team slug
+// @Tags         Groups
+// @Produce      json
+// @Param        team     query     string  true   "Canonical **slug** of the GitHub team"
+// @Param        group    query     string  false  "Suffix of the runner group of the team, selects its default runner group when unset"
+// @Param        dry_run  query     bool    false  "Validate the request and return the change it would make without making it"
+// @Success      200      {object}  JSONResultSuccess{Code=int,Response=string}
+// @Router       /v1/group-delete [delete]
+// @Security     ApiKeyAuth
+func (m *Manager) DoGroupDelete(c *gin.Context) {
+	uuid := requestid.Get(c)
+	team := c.GetString(teamKey)
+
+	m.Logger.WithField("uuid", uuid).WithField("team", team).Info("Retrieving group and dry_run parameters")
+	suffix, ok := m.retrieveGroupSuffix(c)
+	if !ok {
+		return
+	}
+	dryRun, ok := retrieveDryRun(c)
+	if !ok {
 		return
 	}
-	m.Logger.WithField("uuid", uuid).WithField("team", team).Debug("Verified maintainership")
+	m.Logger.WithField("uuid", uuid).WithField("team", team).Debug("Retrieved group and dry_run parameters")
 
 	m.Logger.WithField("uuid", uuid).WithField("team", team).Info("Retrieving runner group ID")
-	groupID, statusCode, err := m.retrieveGroupID(team, uuid)
+	groupID, err := m.retrieveGroupID(team, suffix, uuid)
 	if err != nil {
-		c.JSON(statusCode, &JSONResultError{
-			Code:  statusCode,
-			Error: fmt.Sprintf("Unable to retrieve group ID: %v", err),
-		})
+		writeAPIError(c, err, fmt.Sprintf("Unable to retrieve group ID: %v", err))
 		return
 	}
 	m.Logger.WithField("uuid", uuid).WithField("team", team).Debug("Retrieved runner group ID")
 
+	name, err := m.Config.Groups.GroupName(m.Config.Org, team, suffix)
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, ErrorCodeInternal, fmt.Sprintf("Unable to name runner group: %v", err))
+		return
+	}
+	if dryRun {
+		m.Logger.WithField("uuid", uuid).WithField("team", team).Infof("Dry run, would delete runner group %s", name)
+		writeDryRun(c, http.StatusOK, &dryRunDiff{GroupDeleted: name})
+		return
+	}
+
 	ctx := context.Background()
 	m.Logger.WithField("uuid", uuid).WithField("team", team).Info("Deleting runner group")
 	resp, err := m.ActionsClient.DeleteOrganizationRunnerGroup(ctx, m.Config.Org, *groupID)
 	if err != nil {
-		c.JSON(resp.StatusCode, &JSONResultError{
-			Code:  resp.StatusCode,
-			Error: fmt.Sprintf("Unable to delete runner group: %v", err),
-		})
+		writeAPIError(c, gitHubError(resp, err), fmt.Sprintf("Unable to delete runner group: %v", err))
 		return
 	}
 	m.Logger.WithField("uuid", uuid).WithField("team", team).Debug("Deleted runner group")
 
 	c.JSON(http.StatusOK, &JSONResultSuccess{
 		Code:     http.StatusOK,
-		Response: fmt.Sprintf("Runner group deleted successfully: %s", team),
+		Response: fmt.Sprintf("Runner group deleted successfully: %s", name),
 	})
 }
 
-// DoGroupList   List all resources associated with a GitHub Action organization Runner Group
-// @Summary      List all resources associated with a GitHub Action organization Runner Group
-// @Description  List all repositories and runners assigned to a GitHub Action organization runner group named with the team slug
+// DoGroupsList  List every GitHub Action organization Runner Group of a team
+// @Summary      List every GitHub Action organization Runner Group of a team
+// @Description  Lists every runner group named after the team, including the default runner group of the team and the runner groups told apart by their suffix
 // @Tags         Groups
 // @Produce      json
 // @Param        team  query     string  true  "Canonical **slug** of the GitHub team"
-// @Success      200   {object}  JSONResultSuccess{Code=int,Response=listResponse}
-// @Router       /group-list [get]
+// @Success      200   {object}  JSONResultSuccess{Code=int,Response=[]teamGroup}
+// @Router       /v1/groups-list [get]
 // @Security     ApiKeyAuth
-func (m *Manager) DoGroupList(c *gin.Context) {
+func (m *Manager) DoGroupsList(c *gin.Context) {
 	uuid := requestid.Get(c)
+	team := c.GetString(teamKey)
 
-	m.Logger.Info("Retrieving team parameter")
-	team := c.Query("team")
-	if team == "" {
-		c.JSON(http.StatusBadRequest, &JSONResultError{
-			Code:  http.StatusBadRequest,
-			Error: "Missing required parameter: team",
-		})
+	m.Logger.WithField("uuid", uuid).WithField("team", team).Info("Listing runner groups of team")
+	groups, err := m.listTeamGroups(context.Background(), team)
+	if err != nil {
+		writeAPIError(c, err, fmt.Sprintf("Unable to list runner groups: %v", err))
 		return
 	}
-	m.Logger.WithField("uuid", uuid).WithField("team", team).Debug("Retrieved team parameter")
+	m.Logger.WithField("uuid", uuid).WithField("team", team).Debug("Listed runner groups of team")
 
-	m.Logger.WithField("uuid", uuid).WithField("team", team).Info("Retrieving Authorization header")
-	token := c.GetHeader("Authorization")
-	if token == "" {
-		c.JSON(http.StatusForbidden, &JSONResultError{
-			Code:  http.StatusForbidden,
-			Error: "Missing Authorization header",
+	teamGroups := []*teamGroup{}
+	for _, group := range groups {
+		_, suffix, _ := m.Config.Groups.TeamFor(m.Config.Org, group.GetName())
+		teamGroups = append(teamGroups, &teamGroup{
+			ID:                       group.GetID(),
+			Name:                     group.GetName(),
+			Group:                    suffix,
+			Visibility:               group.GetVisibility(),
+			AllowsPublicRepositories: group.GetAllowsPublicRepositories(),
 		})
-		return
 	}
-	m.Logger.WithField("uuid", uuid).WithField("team", team).Debug("Retrieved Authorization header")
+	sort.Slice(teamGroups, func(i, j int) bool {
+		return teamGroups[i].Group < teamGroups[j].Group
+	})
 
-	m.Logger.WithField("uuid", uuid).WithField("team", team).Info("Verifying maintainership")
-	isMaintainer, err := m.verifyMaintainership(token, team, uuid)
-	if err != nil {
-		c.JSON(http.StatusForbidden, &JSONResultError{
-			Code:  http.StatusForbidden,
-			Error: fmt.Sprintf("Unable to validate user is a team maintainer: %v", err),
-		})
-		return
+	c.JSON(http.StatusOK, &JSONResultSuccess{
+		Code:     http.StatusOK,
+		Response: teamGroups,
+	})
+}
+
+// containsGroup reports whether one of the runner groups has the name
+func containsGroup(groups []*github.RunnerGroup, name string) bool {
+	for _, group := range groups {
+		if group.GetName() == name {
+			return true
+		}
 	}
-	if !isMaintainer {
-		c.JSON(http.StatusUnauthorized, &JSONResultError{
-			Code:  http.StatusUnauthorized,
-			Error: "User is not a maintainer of the team",
-		})
+	return false
+}
+
+// DoGroupList   List all resources associated with a GitHub Action organization Runner Group
+// @Summary      List all resources associated with a GitHub Action organization Runner Group
+// @Description  List all repositories and runners assigned to a GitHub Action organization runner group named with the team slug
+// @Tags         Groups
+// @Produce      json
+// @Param        team   query     string  true   "Canonical **slug** of the GitHub team"
+// @Param        group  query     string  false  "Suffix of the runner group of the team, selects its default runner group when unset"
+// @Success      200    {object}  JSONResultSuccess{Code=int,Response=listResponse}
+// @Router       /v1/group-list [get]
+// @Security     ApiKeyAuth
+func (m *Manager) DoGroupList(c *gin.Context) {
+	uuid := requestid.Get(c)
+	team := c.GetString(teamKey)
+
+	m.Logger.WithField("uuid", uuid).WithField("team", team).Info("Retrieving group parameter")
+	suffix, ok := m.retrieveGroupSuffix(c)
+	if !ok {
 		return
 	}
-	m.Logger.WithField("uuid", uuid).WithField("team", team).Debug("Verified maintainership")
+	m.Logger.WithField("uuid", uuid).WithField("team", team).Debug("Retrieved group parameter")
 
 	m.Logger.WithField("uuid", uuid).WithField("team", team).Info("Retrieving runner group ID")
-	groupID, statusCode, err := m.retrieveGroupID(team, uuid)
+	groupID, err := m.retrieveGroupID(team, suffix, uuid)
 	if err != nil {
-		c.JSON(statusCode, &JSONResultError{
-			Code:  statusCode,
-			Error: fmt.Sprintf("Unable to retrieve group ID: %v", err),
-		})
+		writeAPIError(c, err, fmt.Sprintf("Unable to retrieve group ID: %v", err))
 		return
 	}
 	m.Logger.WithField("uuid", uuid).WithField("team", team).Debug("Retrieved runner group ID")
@@ -263,10 +383,7 @@ func (m *Manager) DoGroupList(c *gin.Context) {
 	for {
 		runnerGroupRunners, resp, err := m.ActionsClient.ListRunnerGroupRunners(ctx, m.Config.Org, *groupID, opts)
 		if err != nil {
-			c.JSON(resp.StatusCode, &JSONResultError{
-				Code:  resp.StatusCode,
-				Error: fmt.Sprintf("Unable to list runners: %v", err),
-			})
+			writeAPIError(c, gitHubError(resp, err), fmt.Sprintf("Unable to list runners: %v", err))
 			return
 		}
 		runners = append(runners, runnerGroupRunners.Runners...)
@@ -290,10 +407,7 @@ func (m *Manager) DoGroupList(c *gin.Context) {
 	for {
 		runnerGroupRepos, resp, err := m.ActionsClient.ListRepositoryAccessRunnerGroup(ctx, m.Config.Org, *groupID, opts)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, &JSONResultError{
-				Code:  http.StatusInternalServerError,
-				Error: fmt.Sprintf("Unable to list repositories: %v", err),
-			})
+			writeAPIError(c, gitHubError(resp, err), fmt.Sprintf("Unable to list repositories: %v", err))
 			return
 		}
 		repos = append(repos, runnerGroupRepos.Repositories...)
@@ -322,7 +436,7 @@ func (m *Manager) DoGroupList(c *gin.Context) {
 	if listResponse.Runners == nil {
 		listResponse.Runners = []string{}
 	}
-	m.Logger.WithField("uuid", uuid).WithField("team", team).Debug(c, "Generated Response")
+	m.Logger.WithField("uuid", uuid).WithField("team", team).Debug("Generated Response")
 
 	c.JSON(http.StatusOK, &JSONResultSuccess{
 		Code:     http.StatusOK,
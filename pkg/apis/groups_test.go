@@ -6,11 +6,13 @@ package apis
 
 import (
 	"encoding/json"
+	"fmt"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
+	"github.com/didip/tollbooth/v6"
 	"github.com/gin-gonic/gin"
 	"github.com/google/go-github/v41/github"
 	"github.com/lindluni/actions-runner-manager/pkg/apis/mocks"
@@ -34,27 +36,29 @@ func TestDoGroupCreate_Success(t *testing.T) {
 				UsersClient: usersClient,
 			}, nil, nil
 		},
+		Limit:  tollbooth.NewLimiter(1, nil),
 		Logger: logger,
+		Router: gin.New(),
 	}
+	manager.SetRoutes()
 
 	runnerGroup := &github.RunnerGroup{
 		Name: github.String("fake-runner-group-name"),
 	}
 	actionsClient.CreateOrganizationRunnerGroupReturns(runnerGroup, nil, nil)
+	actionsClient.ListOrganizationRunnerGroupsReturns(&github.RunnerGroups{}, &github.Response{}, nil)
 
 	membership := &github.Membership{
 		Role: github.String("maintainer"),
 	}
 	teamsClient.GetTeamMembershipBySlugReturns(membership, nil, nil)
 
-	var err error
 	writer := httptest.NewRecorder()
-	context, _ := gin.CreateTestContext(writer)
-	context.Request, err = http.NewRequest(http.MethodGet, "/v1/api/group-add?team=fake-team", nil)
-	context.Request.Header.Set("Authorization", "test-token")
+	request, err := http.NewRequest(http.MethodPost, "/api/v1/group-create?team=fake-team", nil)
 	require.NoError(t, err)
+	request.Header.Set("Authorization", "test-token")
 
-	manager.DoGroupCreate(context)
+	manager.Router.ServeHTTP(writer, request)
 	result := writer.Result()
 	body, err := ioutil.ReadAll(result.Body)
 	defer result.Body.Close()
@@ -72,3 +76,357 @@ func TestDoGroupCreate_Success(t *testing.T) {
 	require.Equal(t, 1, actionsClient.CreateOrganizationRunnerGroupCallCount())
 	require.Equal(t, 1, teamsClient.GetTeamMembershipBySlugCallCount())
 }
+
+func TestDoGroupCreate_Settings(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		query      string
+		groups     Groups
+		code       int
+		error      string
+		visibility string
+		public     bool
+		workflows  []string
+	}{
+		{
+			name:       "defaults",
+			code:       http.StatusOK,
+			visibility: VisibilitySelected,
+		},
+		{
+			name:       "allowed settings",
+			query:      "&visibility=private&allowsPublicRepositories=true&workflows=fake-org/fake-repo/.github/workflows/build.yml@main",
+			groups:     Groups{AllowedVisibilities: []string{VisibilitySelected, VisibilityPrivate}, AllowPublicRepositories: true},
+			code:       http.StatusOK,
+			visibility: VisibilityPrivate,
+			public:     true,
+			workflows:  []string{"fake-org/fake-repo/.github/workflows/build.yml@main"},
+		},
+		{
+			name:  "visibility not allowed",
+			query: "&visibility=all",
+			code:  http.StatusForbidden,
+			error: "Visibility all is not allowed",
+		},
+		{
+			name:  "unknown visibility",
+			query: "&visibility=internal",
+			code:  http.StatusBadRequest,
+			error: "Invalid visibility internal, must be one of selected, private or all",
+		},
+		{
+			name:  "public repositories not allowed",
+			query: "&allowsPublicRepositories=true",
+			code:  http.StatusForbidden,
+			error: "Public repositories are not allowed",
+		},
+	}
+
+	for _, tc := range tests {
+		actionsClient := &mocks.ActionsClient{}
+		actionsClient.CreateOrganizationRunnerGroupReturns(&github.RunnerGroup{ID: github.Int64(2), Name: github.String("fake-team")}, nil, nil)
+		restClient := &mocks.RestClient{}
+		restClient.NewRequestReturns(&http.Request{}, nil)
+		manager := newRunnerManager(actionsClient)
+		manager.RestClient = restClient
+		manager.Config.Groups = tc.groups
+
+		writer := httptest.NewRecorder()
+		request, err := http.NewRequest(http.MethodPost, "/api/v1/group-create?team=fake-team"+tc.query, nil)
+		require.NoError(t, err, tc.name)
+		request.Header.Set("Authorization", "test-token")
+		manager.Router.ServeHTTP(writer, request)
+		require.Equal(t, tc.code, writer.Code, tc.name)
+
+		if tc.error != "" {
+			response := &JSONResultError{}
+			require.NoError(t, json.Unmarshal(writer.Body.Bytes(), response), tc.name)
+			require.Equal(t, tc.error, response.Error, tc.name)
+			require.Equal(t, 0, actionsClient.CreateOrganizationRunnerGroupCallCount(), tc.name)
+			continue
+		}
+		_, org, createReq := actionsClient.CreateOrganizationRunnerGroupArgsForCall(0)
+		require.Equal(t, "fake-org", org, tc.name)
+		require.Equal(t, tc.visibility, createReq.GetVisibility(), tc.name)
+		require.Equal(t, tc.public, createReq.GetAllowsPublicRepositories(), tc.name)
+		if tc.workflows == nil {
+			require.Equal(t, 0, restClient.NewRequestCallCount(), tc.name)
+			continue
+		}
+		method, url, body := restClient.NewRequestArgsForCall(0)
+		require.Equal(t, http.MethodPatch, method, tc.name)
+		require.Equal(t, "orgs/fake-org/actions/runner-groups/2", url, tc.name)
+		require.Equal(t, &runnerGroupWorkflows{RestrictedToWorkflows: true, SelectedWorkflows: tc.workflows}, body, tc.name)
+	}
+}
+
+func TestDoGroupCreate_WorkflowsFailure(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		deleteError error
+		error       string
+	}{
+		{
+			name:  "deleted",
+			error: "Unable to restrict workflows, runner group was not created: fake-error",
+		},
+		{
+			name:        "delete failed",
+			deleteError: fmt.Errorf("fake-delete-error"),
+			error:       "Runner group created but unable to restrict workflows: fake-error, and unable to delete it: fake-delete-error",
+		},
+	}
+	for _, tc := range tests {
+		actionsClient := &mocks.ActionsClient{}
+		actionsClient.CreateOrganizationRunnerGroupReturns(&github.RunnerGroup{ID: github.Int64(2), Name: github.String("fake-team")}, nil, nil)
+		actionsClient.DeleteOrganizationRunnerGroupReturns(nil, tc.deleteError)
+		restClient := &mocks.RestClient{}
+		restClient.NewRequestReturns(&http.Request{}, nil)
+		restClient.DoReturns(&github.Response{Response: &http.Response{StatusCode: http.StatusUnprocessableEntity}}, fmt.Errorf("fake-error"))
+		manager := newRunnerManager(actionsClient)
+		manager.RestClient = restClient
+
+		writer := httptest.NewRecorder()
+		request, err := http.NewRequest(http.MethodPost, "/api/v1/group-create?team=fake-team&workflows=fake-org/fake-repo/.github/workflows/build.yml@main", nil)
+		require.NoError(t, err, tc.name)
+		request.Header.Set("Authorization", "test-token")
+		manager.Router.ServeHTTP(writer, request)
+		require.Equal(t, http.StatusUnprocessableEntity, writer.Code, tc.name)
+
+		response := &JSONResultError{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), response), tc.name)
+		require.Equal(t, tc.error, response.Error, tc.name)
+		require.Equal(t, 1, actionsClient.DeleteOrganizationRunnerGroupCallCount(), tc.name)
+		_, org, groupID := actionsClient.DeleteOrganizationRunnerGroupArgsForCall(0)
+		require.Equal(t, "fake-org", org, tc.name)
+		require.Equal(t, int64(2), groupID, tc.name)
+	}
+}
+
+func TestDoGroupUpdate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		query     string
+		code      int
+		error     string
+		update    *github.UpdateRunnerGroupRequest
+		workflows *runnerGroupWorkflows
+	}{
+		{
+			name:   "visibility",
+			query:  "&visibility=selected&allowsPublicRepositories=false",
+			code:   http.StatusOK,
+			update: &github.UpdateRunnerGroupRequest{Visibility: github.String(VisibilitySelected), AllowsPublicRepositories: github.Bool(false)},
+		},
+		{
+			name:      "restrict workflows",
+			query:     "&workflows=fake-org/fake-repo/.github/workflows/build.yml@main",
+			code:      http.StatusOK,
+			workflows: &runnerGroupWorkflows{RestrictedToWorkflows: true, SelectedWorkflows: []string{"fake-org/fake-repo/.github/workflows/build.yml@main"}},
+		},
+		{
+			name:      "lift workflow restriction",
+			query:     "&workflows=",
+			code:      http.StatusOK,
+			workflows: &runnerGroupWorkflows{SelectedWorkflows: []string{}},
+		},
+		{
+			name:  "no settings",
+			code:  http.StatusBadRequest,
+			error: "Missing required parameter: at least one of visibility, allowsPublicRepositories or workflows",
+		},
+		{
+			name:  "invalid allowsPublicRepositories",
+			query: "&allowsPublicRepositories=maybe",
+			code:  http.StatusBadRequest,
+			error: "Invalid allowsPublicRepositories maybe, must be true or false",
+		},
+	}
+
+	for _, tc := range tests {
+		actionsClient := &mocks.ActionsClient{}
+		actionsClient.UpdateOrganizationRunnerGroupReturns(&github.RunnerGroup{}, &github.Response{}, nil)
+		restClient := &mocks.RestClient{}
+		restClient.NewRequestReturns(&http.Request{}, nil)
+		manager := newRunnerManager(actionsClient)
+		manager.RestClient = restClient
+
+		writer := httptest.NewRecorder()
+		request, err := http.NewRequest(http.MethodPatch, "/api/v1/group-update?team=fake-team"+tc.query, nil)
+		require.NoError(t, err, tc.name)
+		request.Header.Set("Authorization", "test-token")
+		manager.Router.ServeHTTP(writer, request)
+		require.Equal(t, tc.code, writer.Code, tc.name)
+
+		if tc.error != "" {
+			response := &JSONResultError{}
+			require.NoError(t, json.Unmarshal(writer.Body.Bytes(), response), tc.name)
+			require.Equal(t, tc.error, response.Error, tc.name)
+			require.Equal(t, 0, actionsClient.ListOrganizationRunnerGroupsCallCount(), tc.name)
+			continue
+		}
+		if tc.update == nil {
+			require.Equal(t, 0, actionsClient.UpdateOrganizationRunnerGroupCallCount(), tc.name)
+		} else {
+			_, org, groupID, update := actionsClient.UpdateOrganizationRunnerGroupArgsForCall(0)
+			require.Equal(t, "fake-org", org, tc.name)
+			require.Equal(t, int64(2), groupID, tc.name)
+			require.Equal(t, *tc.update, update, tc.name)
+		}
+		if tc.workflows == nil {
+			require.Equal(t, 0, restClient.NewRequestCallCount(), tc.name)
+		} else {
+			_, _, body := restClient.NewRequestArgsForCall(0)
+			require.Equal(t, tc.workflows, body, tc.name)
+		}
+	}
+}
+
+func TestGroups_Validate(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, Groups{}.Validate())
+	require.NoError(t, Groups{AllowedVisibilities: []string{VisibilitySelected, VisibilityAll}}.Validate())
+	require.EqualError(t, Groups{AllowedVisibilities: []string{"internal"}}.Validate(), "unknown visibility internal")
+
+	require.True(t, Groups{}.AllowsVisibility(VisibilitySelected))
+	require.False(t, Groups{}.AllowsVisibility(VisibilityAll))
+	require.True(t, Groups{AllowedVisibilities: []string{VisibilityAll}}.AllowsVisibility(VisibilityAll))
+}
+
+func TestTeamGroups(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		method  string
+		path    string
+		groups  Groups
+		code    int
+		error   string
+		created string
+		deleted int64
+	}{
+		{
+			name:    "create suffixed group",
+			method:  http.MethodPost,
+			path:    "/api/v1/group-create?team=fake-team&group=mem",
+			groups:  Groups{MaxPerTeam: 3},
+			code:    http.StatusOK,
+			created: "fake-team.mem",
+		},
+		{
+			name:   "create beyond maximum",
+			method: http.MethodPost,
+			path:   "/api/v1/group-create?team=fake-team&group=mem",
+			code:   http.StatusForbidden,
+			error:  "Team fake-team already has the maximum of 1 runner groups",
+		},
+		{
+			name:   "invalid group",
+			method: http.MethodPost,
+			path:   "/api/v1/group-create?team=fake-team&group=Large%20Memory",
+			groups: Groups{MaxPerTeam: 3},
+			code:   http.StatusBadRequest,
+			error:  "Invalid group Large Memory, must only contain lowercase letters, digits and hyphens",
+		},
+		{
+			name:   "template without suffix",
+			method: http.MethodPost,
+			path:   "/api/v1/group-create?team=fake-team&group=mem",
+			groups: Groups{NameTemplate: "arm-{{.Team}}"},
+			code:   http.StatusBadRequest,
+			error:  "Group names are not supported by the configured group name template",
+		},
+		{
+			name:    "delete suffixed group",
+			method:  http.MethodDelete,
+			path:    "/api/v1/group-delete?team=fake-team&group=gpu",
+			code:    http.StatusOK,
+			deleted: 5,
+		},
+		{
+			name:    "delete default group",
+			method:  http.MethodDelete,
+			path:    "/api/v1/group-delete?team=fake-team",
+			code:    http.StatusOK,
+			deleted: 2,
+		},
+	}
+
+	for _, tc := range tests {
+		actionsClient := &mocks.ActionsClient{}
+		manager := newRunnerManager(actionsClient)
+		manager.Config.Groups = tc.groups
+		actionsClient.ListOrganizationRunnerGroupsReturns(&github.RunnerGroups{
+			RunnerGroups: []*github.RunnerGroup{
+				{ID: github.Int64(1), Name: github.String("Default"), Default: github.Bool(true)},
+				{ID: github.Int64(2), Name: github.String("fake-team")},
+				{ID: github.Int64(5), Name: github.String("fake-team.gpu")},
+				{ID: github.Int64(6), Name: github.String("fake-team-legacy")},
+			},
+		}, &github.Response{}, nil)
+		actionsClient.CreateOrganizationRunnerGroupReturns(&github.RunnerGroup{ID: github.Int64(7), Name: github.String(tc.created)}, &github.Response{}, nil)
+		actionsClient.DeleteOrganizationRunnerGroupReturns(&github.Response{}, nil)
+
+		writer := httptest.NewRecorder()
+		request, err := http.NewRequest(tc.method, tc.path, nil)
+		require.NoError(t, err, tc.name)
+		request.Header.Set("Authorization", "test-token")
+		manager.Router.ServeHTTP(writer, request)
+		require.Equal(t, tc.code, writer.Code, tc.name)
+
+		if tc.error != "" {
+			response := &JSONResultError{}
+			require.NoError(t, json.Unmarshal(writer.Body.Bytes(), response), tc.name)
+			require.Equal(t, tc.error, response.Error, tc.name)
+			require.Equal(t, 0, actionsClient.CreateOrganizationRunnerGroupCallCount(), tc.name)
+			continue
+		}
+		if tc.created != "" {
+			_, _, createReq := actionsClient.CreateOrganizationRunnerGroupArgsForCall(0)
+			require.Equal(t, tc.created, createReq.GetName(), tc.name)
+		}
+		if tc.deleted != 0 {
+			_, _, groupID := actionsClient.DeleteOrganizationRunnerGroupArgsForCall(0)
+			require.Equal(t, tc.deleted, groupID, tc.name)
+		}
+	}
+}
+
+func TestDoGroupsList(t *testing.T) {
+	t.Parallel()
+
+	actionsClient := &mocks.ActionsClient{}
+	manager := newRunnerManager(actionsClient)
+	actionsClient.ListOrganizationRunnerGroupsReturns(&github.RunnerGroups{
+		RunnerGroups: []*github.RunnerGroup{
+			{ID: github.Int64(1), Name: github.String("Default"), Default: github.Bool(true)},
+			{ID: github.Int64(5), Name: github.String("fake-team.gpu"), Visibility: github.String(VisibilityPrivate)},
+			{ID: github.Int64(2), Name: github.String("fake-team"), Visibility: github.String(VisibilitySelected)},
+			{ID: github.Int64(6), Name: github.String("other-team.gpu")},
+		},
+	}, &github.Response{}, nil)
+
+	writer := httptest.NewRecorder()
+	request, err := http.NewRequest(http.MethodGet, "/api/v1/groups-list?team=fake-team", nil)
+	require.NoError(t, err)
+	request.Header.Set("Authorization", "test-token")
+	manager.Router.ServeHTTP(writer, request)
+	require.Equal(t, http.StatusOK, writer.Code)
+
+	response := &struct {
+		Response []*teamGroup
+	}{}
+	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), response))
+	require.Equal(t, []*teamGroup{
+		{ID: 2, Name: "fake-team", Visibility: VisibilitySelected},
+		{ID: 5, Name: "fake-team.gpu", Group: "gpu", Visibility: VisibilityPrivate},
+	}, response.Response)
+}
@@ -0,0 +1,131 @@
+/**
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package apis
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-contrib/requestid"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// HealthStatusOK reports a passing health check
+	HealthStatusOK = "ok"
+	// HealthStatusFailed reports a failing health check
+	HealthStatusFailed = "failed"
+
+	healthCheckTimeout = 5 * time.Second
+)
+
+// HealthCheck is the result of a single health check
+type HealthCheck struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// HealthResult is the overall result of a health probe along with the result of each of its checks
+type HealthResult struct {
+	Status string                  `json:"status"`
+	Checks map[string]*HealthCheck `json:"checks,omitempty"`
+}
+
+// DoHealthz is the liveness probe, it reports the server process is running and requires no authorization
+func (m *Manager) DoHealthz(c *gin.Context) {
+	c.JSON(http.StatusOK, &JSONResultSuccess{
+		Code:     http.StatusOK,
+		Response: &HealthResult{Status: HealthStatusOK},
+	})
+}
+
+// DoReadyz is the readiness probe, it verifies the configuration is loaded, an installation token can be minted for
+// the GitHub App and the GitHub API is reachable, and requires no authorization
+func (m *Manager) DoReadyz(c *gin.Context) {
+	uuid := requestid.Get(c)
+
+	ctx, cancel := context.WithTimeout(context.Background(), healthCheckTimeout)
+	defer cancel()
+
+	result := &HealthResult{
+		Status: HealthStatusOK,
+		Checks: map[string]*HealthCheck{},
+	}
+	checks := []struct {
+		name  string
+		check func(context.Context) error
+	}{
+		{name: "config", check: m.checkConfig},
+		{name: "installationToken", check: m.checkInstallationToken},
+		{name: "githubAPI", check: m.checkGitHubAPI},
+	}
+	for _, check := range checks {
+		m.Logger.WithField("uuid", uuid).Debugf("Running readiness check %s", check.name)
+		if err := check.check(ctx); err != nil {
+			m.Logger.WithField("uuid", uuid).Errorf("Readiness check %s failed: %v", check.name, err)
+			result.Status = HealthStatusFailed
+			result.Checks[check.name] = &HealthCheck{Status: HealthStatusFailed, Error: err.Error()}
+			continue
+		}
+		result.Checks[check.name] = &HealthCheck{Status: HealthStatusOK}
+	}
+
+	code := http.StatusOK
+	if result.Status != HealthStatusOK {
+		code = http.StatusServiceUnavailable
+	}
+	c.JSON(code, &JSONResultSuccess{
+		Code:     code,
+		Response: result,
+	})
+}
+
+// checkConfig verifies the configuration names the organization and GitHub App installation to manage
+func (m *Manager) checkConfig(context.Context) error {
+	if m.Config == nil {
+		return fmt.Errorf("configuration is not loaded")
+	}
+	if m.Config.AppID == 0 || m.Config.Org == "" && len(m.Organizations) == 0 || m.Config.Org != "" && m.Config.InstallationID == 0 {
+		return fmt.Errorf("configuration is missing org, appID or installationID")
+	}
+	return nil
+}
+
+// checkInstallationToken verifies an installation token can be minted for the GitHub App in every organization
+func (m *Manager) checkInstallationToken(ctx context.Context) error {
+	for _, org := range m.readinessOrganizations() {
+		if org.TokenSource == nil {
+			return fmt.Errorf("no installation token source is configured for organization %s", org.Config.Org)
+		}
+		if _, err := org.TokenSource.Token(ctx); err != nil {
+			return fmt.Errorf("unable to mint installation token for organization %s: %w", org.Config.Org, err)
+		}
+	}
+	return nil
+}
+
+// checkGitHubAPI verifies the GitHub API is reachable by querying the rate limit, which does not count against it
+func (m *Manager) checkGitHubAPI(ctx context.Context) error {
+	for _, org := range m.readinessOrganizations() {
+		req, err := org.RestClient.NewRequest(http.MethodGet, "rate_limit", nil)
+		if err != nil {
+			return fmt.Errorf("unable to create request: %w", err)
+		}
+		if _, err := org.RestClient.Do(ctx, req, nil); err != nil {
+			return fmt.Errorf("unable to reach GitHub API as organization %s: %w", org.Config.Org, err)
+		}
+	}
+	return nil
+}
+
+// readinessOrganizations returns the managers whose clients are checked by the readiness probe
+func (m *Manager) readinessOrganizations() []*Manager {
+	if orgs := m.managedOrganizations(); len(orgs) > 0 {
+		return orgs
+	}
+	return []*Manager{m}
+}
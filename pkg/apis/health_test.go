@@ -0,0 +1,109 @@
+/**
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package apis
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/didip/tollbooth/v6"
+	"github.com/gin-gonic/gin"
+	"github.com/lindluni/actions-runner-manager/pkg/apis/mocks"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealthz(t *testing.T) {
+	t.Parallel()
+
+	logger, _ := test.NewNullLogger()
+	manager := &Manager{
+		Config: &Config{},
+		Limit:  tollbooth.NewLimiter(1, nil),
+		Logger: logger,
+		Router: gin.New(),
+	}
+	manager.SetRoutes()
+
+	writer := httptest.NewRecorder()
+	request, err := http.NewRequest(http.MethodGet, "/healthz", nil)
+	require.NoError(t, err)
+	manager.Router.ServeHTTP(writer, request)
+	require.Equal(t, http.StatusOK, writer.Code)
+	require.JSONEq(t, `{"Code":200,"Response":{"status":"ok"}}`, writer.Body.String())
+}
+
+func TestReadyz(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		config   *Config
+		tokenErr error
+		apiErr   error
+		code     int
+		checks   map[string]string
+	}{
+		{
+			name:   "ready",
+			config: &Config{Org: "fake-org", AppID: 1, InstallationID: 2},
+			code:   http.StatusOK,
+			checks: map[string]string{"config": HealthStatusOK, "installationToken": HealthStatusOK, "githubAPI": HealthStatusOK},
+		},
+		{
+			name:     "token failure",
+			config:   &Config{Org: "fake-org", AppID: 1, InstallationID: 2},
+			tokenErr: fmt.Errorf("fake-error"),
+			code:     http.StatusServiceUnavailable,
+			checks:   map[string]string{"config": HealthStatusOK, "installationToken": HealthStatusFailed, "githubAPI": HealthStatusOK},
+		},
+		{
+			name:   "unreachable api and incomplete config",
+			config: &Config{Org: "fake-org"},
+			apiErr: fmt.Errorf("fake-error"),
+			code:   http.StatusServiceUnavailable,
+			checks: map[string]string{"config": HealthStatusFailed, "installationToken": HealthStatusOK, "githubAPI": HealthStatusFailed},
+		},
+	}
+
+	logger, _ := test.NewNullLogger()
+	for _, tc := range tests {
+		tokenSource := &mocks.TokenSource{}
+		tokenSource.TokenReturns("fake-token", tc.tokenErr)
+		restClient := &mocks.RestClient{}
+		restClient.NewRequestReturns(&http.Request{}, nil)
+		restClient.DoReturns(nil, tc.apiErr)
+		manager := &Manager{
+			Config:      tc.config,
+			Limit:       tollbooth.NewLimiter(1, nil),
+			Logger:      logger,
+			RestClient:  restClient,
+			Router:      gin.New(),
+			TokenSource: tokenSource,
+		}
+		manager.SetRoutes()
+
+		writer := httptest.NewRecorder()
+		request, err := http.NewRequest(http.MethodGet, "/readyz", nil)
+		require.NoError(t, err, tc.name)
+		manager.Router.ServeHTTP(writer, request)
+		require.Equal(t, tc.code, writer.Code, tc.name)
+
+		response := &struct {
+			Code     int
+			Response *HealthResult
+		}{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), response), tc.name)
+		require.Equal(t, tc.code, response.Code, tc.name)
+		for name, status := range tc.checks {
+			require.Equal(t, status, response.Response.Checks[name].Status, tc.name+" "+name)
+		}
+		_, url, _ := restClient.NewRequestArgsForCall(0)
+		require.Equal(t, "rate_limit", url, tc.name)
+	}
+}
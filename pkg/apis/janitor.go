@@ -0,0 +1,301 @@
+/**
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package apis
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-contrib/requestid"
+	"github.com/gin-gonic/gin"
+	"github.com/google/go-github/v41/github"
+	"github.com/google/uuid"
+)
+
+const (
+	janitorUser = "janitor"
+
+	runnerStatusOffline = "offline"
+)
+
+// Janitor configures the background janitor that removes runners which have been offline for longer than
+// OfflineThreshold from the runner groups of the teams that opted in. The janitor is disabled when Interval is unset.
+type Janitor struct {
+	Interval         time.Duration `yaml:"interval"`
+	OfflineThreshold time.Duration `yaml:"offlineThreshold"`
+	DryRun           bool          `yaml:"dryRun"`
+	Teams            []string      `yaml:"teams"`
+}
+
+// Validate verifies an offline threshold is configured when the janitor is enabled
+func (j Janitor) Validate() error {
+	if j.Interval > 0 && j.OfflineThreshold <= 0 {
+		return fmt.Errorf("offlineThreshold must be set when the janitor is enabled")
+	}
+	return nil
+}
+
+// EnabledFor reports whether the team opted in to having its stale runners removed
+func (j Janitor) EnabledFor(team string) bool {
+	for _, enabled := range j.Teams {
+		if enabled == team {
+			return true
+		}
+	}
+	return false
+}
+
+// OfflineRunners remembers when each runner was first observed offline, as the GitHub API does not report when a
+// runner was last seen. A nil OfflineRunners tracks nothing, so no runner is ever considered stale.
+type OfflineRunners struct {
+	now func() time.Time
+
+	mutex sync.Mutex
+	since map[int64]time.Time
+}
+
+// NewOfflineRunners creates an empty tracker of offline runners
+func NewOfflineRunners() *OfflineRunners {
+	return &OfflineRunners{
+		now:   time.Now,
+		since: map[int64]time.Time{},
+	}
+}
+
+// Observe records the current status of the runner and returns when it was first observed offline, or the zero time if
+// it is not offline
+func (o *OfflineRunners) Observe(runner *github.Runner) time.Time {
+	if o == nil {
+		if runner.GetStatus() == runnerStatusOffline {
+			return time.Now()
+		}
+		return time.Time{}
+	}
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	if runner.GetStatus() != runnerStatusOffline {
+		delete(o.since, runner.GetID())
+		return time.Time{}
+	}
+	since, ok := o.since[runner.GetID()]
+	if !ok {
+		since = o.now()
+		o.since[runner.GetID()] = since
+	}
+	return since
+}
+
+// Retain forgets every runner that is not in ids, so runners removed from the organization are not tracked forever
+func (o *OfflineRunners) Retain(ids map[int64]bool) {
+	if o == nil {
+		return
+	}
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	for id := range o.since {
+		if !ids[id] {
+			delete(o.since, id)
+		}
+	}
+}
+
+// Elapsed returns how long ago the time was according to the clock of the tracker
+func (o *OfflineRunners) Elapsed(since time.Time) time.Duration {
+	if o == nil {
+		return time.Since(since)
+	}
+	return o.now().Sub(since)
+}
+
+type offlineRunner struct {
+	ID           int64     `json:"id"`
+	Name         string    `json:"name"`
+	OfflineSince time.Time `json:"offlineSince"`
+	Stale        bool      `json:"stale"`
+}
+
+type janitorReport struct {
+	Team             string           `json:"team"`
+	Enabled          bool             `json:"enabled"`
+	OfflineThreshold string           `json:"offlineThreshold"`
+	Runners          []*offlineRunner `json:"runners"`
+}
+
+// Janitor removes stale runners every configured interval until the context is cancelled
+func (m *Manager) Janitor(ctx context.Context) {
+	ticker := time.NewTicker(m.Config.Janitor.Interval)
+	defer ticker.Stop()
+	for {
+		m.CleanupAll(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// CleanupAll removes the stale runners of every runner group named after a team that opted in to the janitor
+func (m *Manager) CleanupAll(ctx context.Context) {
+	uuid := uuid.NewString()
+	m.Logger.WithField("uuid", uuid).Info("Listing runner groups to clean up")
+	groups, err := m.listRunnerGroups(ctx)
+	if err != nil {
+		m.Logger.WithField("uuid", uuid).Errorf("Unable to list runner groups to clean up: %v", err)
+		return
+	}
+	m.Logger.WithField("uuid", uuid).Debug("Listed runner groups to clean up")
+
+	seen := map[int64]bool{}
+	complete := true
+	for _, group := range groups {
+		team, _, ok := m.Config.Groups.TeamFor(m.Config.Org, group.GetName())
+		if group.GetDefault() || !ok || !m.Config.Janitor.EnabledFor(team) {
+			continue
+		}
+		if err := m.cleanupTeam(ctx, team, group.GetID(), uuid, seen); err != nil {
+			m.Logger.WithField("uuid", uuid).WithField("team", team).Errorf("Unable to clean up runner group: %v", err)
+			complete = false
+		}
+	}
+	// Runners of a group that could not be listed are kept so they do not lose the time they were first seen offline
+	if complete {
+		m.Offline.Retain(seen)
+	}
+}
+
+// cleanupTeam removes the stale runners of the runner group, only logging them in dry-run mode, and records the ID of
+// every runner in the group in seen
+func (m *Manager) cleanupTeam(ctx context.Context, team string, groupID int64, uuid string, seen map[int64]bool) error {
+	m.Logger.WithField("uuid", uuid).WithField("team", team).Info("Searching runner group for stale runners")
+	runners, _, err := m.listGroupRunners(ctx, groupID)
+	if err != nil {
+		return fmt.Errorf("unable to list runners: %w", err)
+	}
+	for _, runner := range runners {
+		seen[runner.GetID()] = true
+	}
+	stale := m.offlineRunners(runners)
+	m.Logger.WithField("uuid", uuid).WithField("team", team).Debug("Searched runner group for stale runners")
+
+	for _, runner := range stale {
+		if !runner.Stale {
+			continue
+		}
+		if m.Config.Janitor.DryRun {
+			m.Logger.WithField("uuid", uuid).WithField("team", team).Infof("Dry run, would remove runner %s offline since %s", runner.Name, runner.OfflineSince.Format(time.RFC3339))
+			continue
+		}
+
+		m.Logger.WithField("uuid", uuid).WithField("team", team).Infof("Removing runner %s offline since %s", runner.Name, runner.OfflineSince.Format(time.RFC3339))
+		resp, err := m.ActionsClient.RemoveOrganizationRunner(ctx, m.Config.Org, runner.ID)
+		code := http.StatusNoContent
+		if resp != nil && resp.Response != nil {
+			code = resp.StatusCode
+		} else if err != nil {
+			code = http.StatusInternalServerError
+		}
+		m.auditJanitor(team, uuid, runner.Name, code)
+		if err != nil {
+			m.Logger.WithField("uuid", uuid).WithField("team", team).Errorf("Unable to remove runner %s: %v", runner.Name, err)
+			continue
+		}
+		m.Logger.WithField("uuid", uuid).WithField("team", team).Debugf("Removed runner %s", runner.Name)
+	}
+	return nil
+}
+
+// offlineRunners reports the runners that are offline, flagging those offline for longer than the threshold as stale.
+// Busy runners are never stale.
+func (m *Manager) offlineRunners(runners []*github.Runner) []*offlineRunner {
+	offline := []*offlineRunner{}
+	for _, runner := range runners {
+		since := m.Offline.Observe(runner)
+		if since.IsZero() {
+			continue
+		}
+		offline = append(offline, &offlineRunner{
+			ID:           runner.GetID(),
+			Name:         runner.GetName(),
+			OfflineSince: since.UTC(),
+			Stale:        !runner.GetBusy() && m.Offline.Elapsed(since) >= m.Config.Janitor.OfflineThreshold,
+		})
+	}
+	return offline
+}
+
+// auditJanitor records the removal of a runner by the janitor when an audit log is configured
+func (m *Manager) auditJanitor(team, uuid, runner string, code int) {
+	if m.AuditLog == nil {
+		return
+	}
+	err := m.AuditLog.Write(&AuditEvent{
+		Time:      time.Now().UTC(),
+		RequestID: uuid,
+		Org:       m.Config.Org,
+		User:      janitorUser,
+		Team:      team,
+		Operation: OperationRunnerDelete,
+		Runner:    runner,
+		Code:      code,
+	})
+	if err != nil {
+		m.Logger.WithField("uuid", uuid).WithField("team", team).Errorf("Unable to write audit event: %v", err)
+	}
+}
+
+// DoJanitorReport List the offline runners of a GitHub Actions organization runner group
+// @Summary      List the offline runners of a GitHub Actions organization runner group
+// @Description  Lists the runners of the runner group named with the team slug that are offline, when they were first observed offline and whether the janitor considers them stale. No runners are removed.
+// @Tags         Runners
+// @Produce      json
+// @Param        team   query     string  true   "Canonical **slug** of the GitHub team"
+// @Param        group  query     string  false  "Suffix of the runner group of the team, selects its default runner group when unset"
+// @Success      200    {object}  JSONResultSuccess{Code=int,Response=janitorReport}
+// @Router       /v1/janitor-report [get]
+// @Security     ApiKeyAuth
+func (m *Manager) DoJanitorReport(c *gin.Context) {
+	uuid := requestid.Get(c)
+	team := c.GetString(teamKey)
+
+	m.Logger.WithField("uuid", uuid).WithField("team", team).Info("Retrieving group parameter")
+	suffix, ok := m.retrieveGroupSuffix(c)
+	if !ok {
+		return
+	}
+	m.Logger.WithField("uuid", uuid).WithField("team", team).Debug("Retrieved group parameter")
+
+	m.Logger.WithField("uuid", uuid).WithField("team", team).Info("Retrieving runner group ID")
+	groupID, err := m.retrieveGroupID(team, suffix, uuid)
+	if err != nil {
+		writeAPIError(c, err, fmt.Sprintf("Unable to retrieve group ID: %v", err))
+		return
+	}
+	m.Logger.WithField("uuid", uuid).WithField("team", team).Debug("Retrieved runner group ID")
+
+	m.Logger.WithField("uuid", uuid).WithField("team", team).Info("Searching runner group for offline runners")
+	runners, resp, err := m.listGroupRunners(context.Background(), *groupID)
+	if err != nil {
+		writeAPIError(c, gitHubError(resp, err), fmt.Sprintf("Unable to list runners: %v", err))
+		return
+	}
+	report := &janitorReport{
+		Team:             team,
+		Enabled:          m.Config.Janitor.Interval > 0 && m.Config.Janitor.EnabledFor(team),
+		OfflineThreshold: m.Config.Janitor.OfflineThreshold.String(),
+		Runners:          m.offlineRunners(runners),
+	}
+	m.Logger.WithField("uuid", uuid).WithField("team", team).Debug("Searched runner group for offline runners")
+
+	c.JSON(http.StatusOK, &JSONResultSuccess{
+		Code:     http.StatusOK,
+		Response: report,
+	})
+}
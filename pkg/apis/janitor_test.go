@@ -0,0 +1,177 @@
+/**
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package apis
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v41/github"
+	"github.com/lindluni/actions-runner-manager/pkg/apis/mocks"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJanitor_Validate(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, Janitor{}.Validate())
+	require.NoError(t, Janitor{Interval: time.Minute, OfflineThreshold: time.Hour}.Validate())
+	require.EqualError(t, Janitor{Interval: time.Minute}.Validate(), "offlineThreshold must be set when the janitor is enabled")
+
+	janitor := Janitor{Teams: []string{"fake-team"}}
+	require.True(t, janitor.EnabledFor("fake-team"))
+	require.False(t, janitor.EnabledFor("other-team"))
+}
+
+func TestOfflineRunners(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2021, 12, 1, 0, 0, 0, 0, time.UTC)
+	offline := NewOfflineRunners()
+	offline.now = func() time.Time { return now }
+
+	runner := &github.Runner{ID: github.Int64(3), Status: github.String("offline")}
+	require.Equal(t, now, offline.Observe(runner))
+	now = now.Add(time.Hour)
+	require.Equal(t, now.Add(-time.Hour), offline.Observe(runner))
+	require.Equal(t, time.Hour, offline.Elapsed(now.Add(-time.Hour)))
+
+	runner.Status = github.String("online")
+	require.True(t, offline.Observe(runner).IsZero())
+	runner.Status = github.String("offline")
+	require.Equal(t, now, offline.Observe(runner))
+
+	offline.Retain(map[int64]bool{})
+	require.Empty(t, offline.since)
+
+	var untracked *OfflineRunners
+	require.False(t, untracked.Observe(runner).IsZero())
+	untracked.Retain(map[int64]bool{})
+}
+
+func TestCleanupAll(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		teams   []string
+		dryRun  bool
+		elapsed time.Duration
+		removed bool
+	}{
+		{name: "stale", teams: []string{"fake-team"}, elapsed: 2 * time.Hour, removed: true},
+		{name: "below threshold", teams: []string{"fake-team"}, elapsed: 30 * time.Minute},
+		{name: "dry run", teams: []string{"fake-team"}, dryRun: true, elapsed: 2 * time.Hour},
+		{name: "not opted in", elapsed: 2 * time.Hour},
+	}
+
+	logger, _ := test.NewNullLogger()
+	for _, tc := range tests {
+		actionsClient := &mocks.ActionsClient{}
+		actionsClient.ListOrganizationRunnerGroupsReturns(&github.RunnerGroups{
+			RunnerGroups: []*github.RunnerGroup{
+				{ID: github.Int64(1), Name: github.String("Default"), Default: github.Bool(true)},
+				{ID: github.Int64(2), Name: github.String("fake-team")},
+			},
+		}, &github.Response{}, nil)
+		actionsClient.ListRunnerGroupRunnersReturns(&github.Runners{
+			Runners: []*github.Runner{
+				{ID: github.Int64(3), Name: github.String("dead-runner"), Status: github.String("offline")},
+				{ID: github.Int64(4), Name: github.String("live-runner"), Status: github.String("online")},
+			},
+		}, &github.Response{}, nil)
+		actionsClient.RemoveOrganizationRunnerReturns(&github.Response{Response: &http.Response{StatusCode: http.StatusNoContent}}, nil)
+		sink, err := NewFileAuditSink(filepath.Join(t.TempDir(), "audit.jsonl"))
+		require.NoError(t, err, tc.name)
+
+		now := time.Date(2021, 12, 1, 0, 0, 0, 0, time.UTC)
+		offline := NewOfflineRunners()
+		offline.now = func() time.Time { return now }
+		manager := &Manager{
+			ActionsClient: actionsClient,
+			AuditLog:      sink,
+			Config: &Config{
+				Org: "fake-org",
+				Janitor: Janitor{
+					Interval:         time.Minute,
+					OfflineThreshold: time.Hour,
+					DryRun:           tc.dryRun,
+					Teams:            tc.teams,
+				},
+			},
+			Logger:  logger,
+			Offline: offline,
+		}
+
+		manager.CleanupAll(context.Background())
+		require.Equal(t, 0, actionsClient.RemoveOrganizationRunnerCallCount(), tc.name)
+		now = now.Add(tc.elapsed)
+		manager.CleanupAll(context.Background())
+
+		events, err := sink.Query(&AuditFilter{})
+		require.NoError(t, err, tc.name)
+		require.NoError(t, sink.Close(), tc.name)
+		if !tc.removed {
+			require.Equal(t, 0, actionsClient.RemoveOrganizationRunnerCallCount(), tc.name)
+			require.Empty(t, events, tc.name)
+			continue
+		}
+		require.Equal(t, 1, actionsClient.RemoveOrganizationRunnerCallCount(), tc.name)
+		_, org, runnerID := actionsClient.RemoveOrganizationRunnerArgsForCall(0)
+		require.Equal(t, "fake-org", org, tc.name)
+		require.Equal(t, int64(3), runnerID, tc.name)
+		require.Len(t, events, 1, tc.name)
+		require.Equal(t, janitorUser, events[0].User, tc.name)
+		require.Equal(t, OperationRunnerDelete, events[0].Operation, tc.name)
+		require.Equal(t, "dead-runner", events[0].Runner, tc.name)
+		require.Equal(t, http.StatusNoContent, events[0].Code, tc.name)
+	}
+}
+
+func TestDoJanitorReport(t *testing.T) {
+	t.Parallel()
+
+	actionsClient := &mocks.ActionsClient{}
+	manager := newRunnerManager(actionsClient)
+	now := time.Date(2021, 12, 1, 0, 0, 0, 0, time.UTC)
+	manager.Offline = NewOfflineRunners()
+	manager.Offline.now = func() time.Time { return now }
+	manager.Config.Janitor = Janitor{Interval: time.Minute, OfflineThreshold: time.Hour, Teams: []string{"fake-team"}}
+
+	report := func() *janitorReport {
+		writer := httptest.NewRecorder()
+		request, err := http.NewRequest(http.MethodGet, "/api/v1/janitor-report?team=fake-team", nil)
+		require.NoError(t, err)
+		request.Header.Set("Authorization", "test-token")
+		manager.Router.ServeHTTP(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+
+		response := &struct {
+			Code     int
+			Response *janitorReport
+		}{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), response))
+		return response.Response
+	}
+
+	expected := &janitorReport{
+		Team:             "fake-team",
+		Enabled:          true,
+		OfflineThreshold: "1h0m0s",
+		Runners:          []*offlineRunner{{ID: 3, Name: "fake-runner", OfflineSince: now}},
+	}
+	require.Equal(t, expected, report())
+
+	now = now.Add(2 * time.Hour)
+	expected.Runners[0].Stale = true
+	require.Equal(t, expected, report())
+	require.Equal(t, 0, actionsClient.RemoveOrganizationRunnerCallCount())
+}
@@ -0,0 +1,277 @@
+/**
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package apis
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-contrib/requestid"
+	"github.com/gin-gonic/gin"
+	"github.com/google/go-github/v41/github"
+)
+
+// reservedLabels are applied to runners by GitHub and cannot be added, replaced or removed through the API
+var reservedLabels = map[string]bool{
+	selfHostedLabel: true,
+	"linux":         true,
+	"windows":       true,
+	"macos":         true,
+	"x64":           true,
+	"arm":           true,
+	"arm64":         true,
+}
+
+type runnerLabelsRequest struct {
+	Labels []string `json:"labels"`
+}
+
+type runnerLabelsResponse struct {
+	TotalCount int            `json:"total_count"`
+	Labels     []*runnerLabel `json:"labels"`
+}
+
+// isReservedLabel reports whether the label is applied to runners by GitHub
+func isReservedLabel(label string) bool {
+	return reservedLabels[strings.ToLower(label)]
+}
+
+// customLabels returns the names of the labels of the runner that are not reserved
+func customLabels(runner *github.Runner) []string {
+	labels := []string{}
+	for _, label := range runner.Labels {
+		if label.GetType() == "read-only" || isReservedLabel(label.GetName()) {
+			continue
+		}
+		labels = append(labels, label.GetName())
+	}
+	return labels
+}
+
+// retrieveLabelsParameter parses the labels parameter, writing the error response and returning nil if it is missing or
+// names a reserved label
+func retrieveLabelsParameter(c *gin.Context) []string {
+	param := c.Query("labels")
+	if param == "" {
+		writeError(c, http.StatusBadRequest, ErrorCodeBadRequest, "Missing required parameter: labels")
+		return nil
+	}
+
+	labels := parseLabels(param)
+	for _, label := range labels {
+		if isReservedLabel(label) {
+			writeError(c, http.StatusBadRequest, ErrorCodeBadRequest, fmt.Sprintf("Label %s is reserved and cannot be managed", label))
+			return nil
+		}
+	}
+	if len(labels) == 0 {
+		writeError(c, http.StatusBadRequest, ErrorCodeBadRequest, "Missing required parameter: labels")
+		return nil
+	}
+	return labels
+}
+
+// parseLabels splits a comma-separated list of labels, trimming each label and dropping empty and duplicate labels
+func parseLabels(param string) []string {
+	var labels []string
+	seen := map[string]bool{}
+	for _, label := range strings.Split(param, ",") {
+		label = strings.TrimSpace(label)
+		if label == "" || seen[label] {
+			continue
+		}
+		seen[label] = true
+		labels = append(labels, label)
+	}
+	return labels
+}
+
+// setRunnerLabels replaces the custom labels of the runner. The API is not yet exposed by the go-github ActionsService,
+// so the request is sent through the underlying REST client.
+func (m *Manager) setRunnerLabels(ctx context.Context, runnerID int64, labels []string) ([]*runnerLabel, *github.Response, error) {
+	u := fmt.Sprintf("orgs/%v/actions/runners/%v/labels", m.Config.Org, runnerID)
+	req, err := m.RestClient.NewRequest(http.MethodPut, u, &runnerLabelsRequest{Labels: labels})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	response := &runnerLabelsResponse{}
+	resp, err := m.RestClient.Do(ctx, req, response)
+	if err != nil {
+		return nil, resp, err
+	}
+	return response.Labels, resp, nil
+}
+
+// updateRunnerLabels finds the runner in the runner group of the team and replaces its custom labels with the labels
+// returned by update
+func (m *Manager) updateRunnerLabels(c *gin.Context, update func(current, labels []string) ([]string, error)) {
+	uuid := requestid.Get(c)
+	team := c.GetString(teamKey)
+
+	m.Logger.WithField("uuid", uuid).WithField("team", team).Info("Retrieving group, runner and labels parameters")
+	suffix, ok := m.retrieveGroupSuffix(c)
+	if !ok {
+		return
+	}
+	name := c.Query("runner")
+	if name == "" {
+		writeError(c, http.StatusBadRequest, ErrorCodeBadRequest, "Missing required parameter: runner")
+		return
+	}
+	labels := retrieveLabelsParameter(c)
+	if labels == nil {
+		return
+	}
+	m.Logger.WithField("uuid", uuid).WithField("team", team).Debug("Retrieved group, runner and labels parameters")
+
+	runner := m.findGroupRunner(c, team, suffix, name, uuid)
+	if runner == nil {
+		return
+	}
+
+	desired, err := update(customLabels(runner), labels)
+	if err != nil {
+		writeError(c, http.StatusBadRequest, ErrorCodeBadRequest, fmt.Sprintf("Unable to update runner labels: %v", err))
+		return
+	}
+
+	m.Logger.WithField("uuid", uuid).WithField("team", team).Infof("Setting custom labels of runner %s to %v", name, desired)
+	updated, resp, err := m.setRunnerLabels(context.Background(), runner.GetID(), desired)
+	if err != nil {
+		writeAPIError(c, gitHubError(resp, err), fmt.Sprintf("Unable to set runner labels: %v", err))
+		return
+	}
+	m.Logger.WithField("uuid", uuid).WithField("team", team).Debugf("Set custom labels of runner %s", name)
+
+	if updated == nil {
+		updated = []*runnerLabel{}
+	}
+	c.JSON(http.StatusOK, &JSONResultSuccess{
+		Code:     http.StatusOK,
+		Response: updated,
+	})
+}
+
+// DoLabelsList  List the labels of a runner in a GitHub Actions organization runner group
+// @Summary      List the labels of a runner in a GitHub Actions organization runner group
+// @Description  Lists the labels of a runner, identified by name or ID, that belongs to the runner group named with the team slug
+// @Tags         Labels
+// @Produce      json
+// @Param        team    query     string  true   "Canonical **slug** of the GitHub team"
+// @Param        group   query     string  false  "Suffix of the runner group of the team, selects its default runner group when unset"
+// @Param        runner  query     string  true   "Name or ID of the runner"
+// @Success      200     {object}  JSONResultSuccess{Code=int,Response=[]runnerLabel}
+// @Router       /v1/labels-list [get]
+// @Security     ApiKeyAuth
+func (m *Manager) DoLabelsList(c *gin.Context) {
+	uuid := requestid.Get(c)
+	team := c.GetString(teamKey)
+
+	m.Logger.WithField("uuid", uuid).WithField("team", team).Info("Retrieving group and runner parameters")
+	suffix, ok := m.retrieveGroupSuffix(c)
+	if !ok {
+		return
+	}
+	name := c.Query("runner")
+	if name == "" {
+		writeError(c, http.StatusBadRequest, ErrorCodeBadRequest, "Missing required parameter: runner")
+		return
+	}
+	m.Logger.WithField("uuid", uuid).WithField("team", team).Debug("Retrieved group and runner parameters")
+
+	runner := m.findGroupRunner(c, team, suffix, name, uuid)
+	if runner == nil {
+		return
+	}
+
+	c.JSON(http.StatusOK, &JSONResultSuccess{
+		Code:     http.StatusOK,
+		Response: newRunnerDetails(runner).Labels,
+	})
+}
+
+// DoLabelsAdd   Add custom labels to a runner in a GitHub Actions organization runner group
+// @Summary      Add custom labels to a runner in a GitHub Actions organization runner group
+// @Description  Adds custom labels to a runner, identified by name or ID, that belongs to the runner group named with the team slug
+// @Tags         Labels
+// @Produce      json
+// @Param        team    query     string    true   "Canonical **slug** of the GitHub team"
+// @Param        group   query     string    false  "Suffix of the runner group of the team, selects its default runner group when unset"
+// @Param        runner  query     string    true   "Name or ID of the runner"
+// @Param        labels  query     []string  true   "Comma-seperated list of custom labels"
+// @Success      200     {object}  JSONResultSuccess{Code=int,Response=[]runnerLabel}
+// @Router       /v1/labels-add [patch]
+// @Security     ApiKeyAuth
+func (m *Manager) DoLabelsAdd(c *gin.Context) {
+	m.updateRunnerLabels(c, func(current, labels []string) ([]string, error) {
+		desired := current
+		for _, label := range labels {
+			if !containsLabel(desired, label) {
+				desired = append(desired, label)
+			}
+		}
+		return desired, nil
+	})
+}
+
+// DoLabelsRemove Remove custom labels from a runner in a GitHub Actions organization runner group
+// @Summary      Remove custom labels from a runner in a GitHub Actions organization runner group
+// @Description  Removes custom labels from a runner, identified by name or ID, that belongs to the runner group named with the team slug. Reserved labels cannot be removed.
+// @Tags         Labels
+// @Produce      json
+// @Param        team    query     string    true   "Canonical **slug** of the GitHub team"
+// @Param        group   query     string    false  "Suffix of the runner group of the team, selects its default runner group when unset"
+// @Param        runner  query     string    true   "Name or ID of the runner"
+// @Param        labels  query     []string  true   "Comma-seperated list of custom labels"
+// @Success      200     {object}  JSONResultSuccess{Code=int,Response=[]runnerLabel}
+// @Router       /v1/labels-remove [patch]
+// @Security     ApiKeyAuth
+func (m *Manager) DoLabelsRemove(c *gin.Context) {
+	m.updateRunnerLabels(c, func(current, labels []string) ([]string, error) {
+		for _, label := range labels {
+			if !containsLabel(current, label) {
+				return nil, fmt.Errorf("runner does not have label %s", label)
+			}
+		}
+		desired := []string{}
+		for _, label := range current {
+			if !containsLabel(labels, label) {
+				desired = append(desired, label)
+			}
+		}
+		return desired, nil
+	})
+}
+
+// DoLabelsSet   Replace the custom labels of a runner in a GitHub Actions organization runner group
+// @Summary      Replace the custom labels of a runner in a GitHub Actions organization runner group
+// @Description  Replaces all custom labels of a runner, identified by name or ID, that belongs to the runner group named with the team slug. Reserved labels are kept.
+// @Tags         Labels
+// @Produce      json
+// @Param        team    query     string    true   "Canonical **slug** of the GitHub team"
+// @Param        group   query     string    false  "Suffix of the runner group of the team, selects its default runner group when unset"
+// @Param        runner  query     string    true   "Name or ID of the runner"
+// @Param        labels  query     []string  true   "Comma-seperated list of custom labels"
+// @Success      200     {object}  JSONResultSuccess{Code=int,Response=[]runnerLabel}
+// @Router       /v1/labels-set [patch]
+// @Security     ApiKeyAuth
+func (m *Manager) DoLabelsSet(c *gin.Context) {
+	m.updateRunnerLabels(c, func(_, labels []string) ([]string, error) {
+		return labels, nil
+	})
+}
+
+// containsLabel reports whether the labels contain the label, ignoring case as GitHub does
+func containsLabel(labels []string, label string) bool {
+	for _, existing := range labels {
+		if strings.EqualFold(existing, label) {
+			return true
+		}
+	}
+	return false
+}
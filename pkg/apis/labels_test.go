@@ -0,0 +1,161 @@
+/**
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package apis
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-github/v41/github"
+	"github.com/lindluni/actions-runner-manager/pkg/apis/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDoLabels(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		method   string
+		url      string
+		expected *JSONResultError
+		labels   []string
+	}{
+		{
+			name:     "add",
+			method:   http.MethodPatch,
+			url:      "/api/v1/labels-add?team=fake-team&runner=fake-runner&labels=gpu,large-disk",
+			expected: &JSONResultError{Code: http.StatusOK},
+			labels:   []string{"large-disk", "gpu"},
+		},
+		{
+			name:     "remove",
+			method:   http.MethodPatch,
+			url:      "/api/v1/labels-remove?team=fake-team&runner=3&labels=large-disk",
+			expected: &JSONResultError{Code: http.StatusOK},
+			labels:   []string{},
+		},
+		{
+			name:     "set",
+			method:   http.MethodPatch,
+			url:      "/api/v1/labels-set?team=fake-team&runner=fake-runner&labels=arm64-builder",
+			expected: &JSONResultError{Code: http.StatusOK},
+			labels:   []string{"arm64-builder"},
+		},
+		{
+			name:   "remove reserved",
+			method: http.MethodPatch,
+			url:    "/api/v1/labels-remove?team=fake-team&runner=fake-runner&labels=self-hosted",
+			expected: &JSONResultError{
+				Code:      http.StatusBadRequest,
+				Error:     "Label self-hosted is reserved and cannot be managed",
+				ErrorCode: ErrorCodeBadRequest,
+			},
+		},
+		{
+			name:   "remove missing",
+			method: http.MethodPatch,
+			url:    "/api/v1/labels-remove?team=fake-team&runner=fake-runner&labels=gpu",
+			expected: &JSONResultError{
+				Code:      http.StatusBadRequest,
+				Error:     "Unable to update runner labels: runner does not have label gpu",
+				ErrorCode: ErrorCodeBadRequest,
+			},
+		},
+		{
+			name:   "missing labels",
+			method: http.MethodPatch,
+			url:    "/api/v1/labels-add?team=fake-team&runner=fake-runner&labels=,",
+			expected: &JSONResultError{
+				Code:      http.StatusBadRequest,
+				Error:     "Missing required parameter: labels",
+				ErrorCode: ErrorCodeBadRequest,
+			},
+		},
+		{
+			name:   "runner in another group",
+			method: http.MethodPatch,
+			url:    "/api/v1/labels-set?team=fake-team&runner=other-runner&labels=gpu",
+			expected: &JSONResultError{
+				Code:      http.StatusNotFound,
+				Error:     "Unable to locate runner other-runner in the runner group of the team",
+				ErrorCode: ErrorCodeNotFound,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		actionsClient := &mocks.ActionsClient{}
+		manager := newRunnerManager(actionsClient)
+		actionsClient.ListRunnerGroupRunnersReturns(&github.Runners{
+			Runners: []*github.Runner{{
+				ID:   github.Int64(3),
+				Name: github.String("fake-runner"),
+				Labels: []*github.RunnerLabels{
+					{ID: github.Int64(1), Name: github.String("self-hosted"), Type: github.String("read-only")},
+					{ID: github.Int64(2), Name: github.String("Linux"), Type: github.String("read-only")},
+					{ID: github.Int64(3), Name: github.String("large-disk"), Type: github.String("custom")},
+				},
+			}},
+		}, &github.Response{}, nil)
+		restClient := &mocks.RestClient{}
+		restClient.NewRequestStub = func(method, urlStr string, body interface{}) (*http.Request, error) {
+			return github.NewClient(nil).NewRequest(method, urlStr, body)
+		}
+		restClient.DoStub = func(_ context.Context, _ *http.Request, v interface{}) (*github.Response, error) {
+			v.(*runnerLabelsResponse).Labels = []*runnerLabel{{ID: 1, Name: "self-hosted", Type: "read-only"}}
+			return &github.Response{Response: &http.Response{StatusCode: http.StatusOK}}, nil
+		}
+		manager.RestClient = restClient
+		sink, err := NewFileAuditSink(filepath.Join(t.TempDir(), "audit.jsonl"))
+		require.NoError(t, err, tc.name)
+		t.Cleanup(func() { sink.Close() })
+		manager.AuditLog = sink
+
+		writer := httptest.NewRecorder()
+		request, err := http.NewRequest(tc.method, tc.url, nil)
+		require.NoError(t, err, tc.name)
+		request.Header.Set("Authorization", "test-token")
+		manager.Router.ServeHTTP(writer, request)
+
+		require.Equal(t, tc.expected.Code, writer.Code, tc.name)
+		if tc.expected.Error != "" {
+			response := &JSONResultError{}
+			require.NoError(t, json.Unmarshal(writer.Body.Bytes(), response), tc.name)
+			require.Equal(t, tc.expected, response, tc.name)
+			require.Equal(t, 0, restClient.DoCallCount(), tc.name)
+			continue
+		}
+		require.Equal(t, 1, restClient.NewRequestCallCount(), tc.name)
+		method, url, body := restClient.NewRequestArgsForCall(0)
+		require.Equal(t, http.MethodPut, method, tc.name)
+		require.Equal(t, "orgs/fake-org/actions/runners/3/labels", url, tc.name)
+		require.Equal(t, &runnerLabelsRequest{Labels: tc.labels}, body, tc.name)
+
+		events, err := sink.Query(&AuditFilter{Team: "fake-team"})
+		require.NoError(t, err, tc.name)
+		require.Len(t, events, 1, tc.name)
+		require.Equal(t, "fake-runner", events[0].Runner, tc.name)
+	}
+}
+
+func TestDoLabelsList(t *testing.T) {
+	t.Parallel()
+
+	manager := newRunnerManager(&mocks.ActionsClient{})
+
+	writer := httptest.NewRecorder()
+	request, err := http.NewRequest(http.MethodGet, "/api/v1/labels-list?team=fake-team&runner=fake-runner", nil)
+	require.NoError(t, err)
+	request.Header.Set("Authorization", "test-token")
+	manager.Router.ServeHTTP(writer, request)
+
+	require.Equal(t, http.StatusOK, writer.Code)
+	require.JSONEq(t, `{"Code":200,"Response":[{"id":1,"name":"self-hosted","type":"read-only"}]}`, writer.Body.String())
+}
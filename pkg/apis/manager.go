@@ -11,6 +11,7 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/didip/tollbooth/v6"
 	"github.com/didip/tollbooth/v6/limiter"
@@ -29,16 +30,36 @@ type actionsClient interface {
 	CreateOrganizationRemoveToken(ctx context.Context, owner string) (*github.RemoveToken, *github.Response, error)
 	CreateOrganizationRunnerGroup(ctx context.Context, org string, createReq github.CreateRunnerGroupRequest) (*github.RunnerGroup, *github.Response, error)
 	DeleteOrganizationRunnerGroup(ctx context.Context, org string, groupID int64) (*github.Response, error)
+	GetOrganizationRunner(ctx context.Context, owner string, runnerID int64) (*github.Runner, *github.Response, error)
 	ListOrganizationRunnerGroups(ctx context.Context, org string, opts *github.ListOptions) (*github.RunnerGroups, *github.Response, error)
 	ListRepositoryAccessRunnerGroup(ctx context.Context, org string, groupID int64, opts *github.ListOptions) (*github.ListRepositories, *github.Response, error)
 	ListRunnerGroupRunners(ctx context.Context, org string, groupID int64, opts *github.ListOptions) (*github.Runners, *github.Response, error)
+	RemoveOrganizationRunner(ctx context.Context, owner string, runnerID int64) (*github.Response, error)
 	RemoveRepositoryAccessRunnerGroup(ctx context.Context, org string, groupID, repoID int64) (*github.Response, error)
 	SetRepositoryAccessRunnerGroup(ctx context.Context, org string, groupID int64, ids github.SetRepoAccessRunnerGroupRequest) (*github.Response, error)
+	UpdateOrganizationRunnerGroup(ctx context.Context, org string, groupID int64, updateReq github.UpdateRunnerGroupRequest) (*github.RunnerGroup, *github.Response, error)
+}
+
+//counterfeiter:generate -o mocks/apps_client.go -fake-name AppsClient . appsClient
+type appsClient interface {
+	FindOrganizationInstallation(ctx context.Context, org string) (*github.Installation, *github.Response, error)
+	ListInstallations(ctx context.Context, opts *github.ListOptions) ([]*github.Installation, *github.Response, error)
+}
+
+//counterfeiter:generate -o mocks/organizations_client.go -fake-name OrganizationsClient . organizationsClient
+type organizationsClient interface {
+	GetOrgMembership(ctx context.Context, user, org string) (*github.Membership, *github.Response, error)
+}
+
+//counterfeiter:generate -o mocks/token_source.go -fake-name TokenSource . tokenSource
+type tokenSource interface {
+	Token(ctx context.Context) (string, error)
 }
 
 //counterfeiter:generate -o mocks/teams_client.go -fake-name TeamsClient . teamsClient
 type teamsClient interface {
 	GetTeamMembershipBySlug(ctx context.Context, org, slug, user string) (*github.Membership, *github.Response, error)
+	GetTeamBySlug(ctx context.Context, org, slug string) (*github.Team, *github.Response, error)
 	ListTeamReposBySlug(ctx context.Context, org, slug string, opts *github.ListOptions) ([]*github.Repository, *github.Response, error)
 }
 
@@ -47,18 +68,39 @@ type usersClient interface {
 	Get(ctx context.Context, user string) (*github.User, *github.Response, error)
 }
 
+//counterfeiter:generate -o mocks/rest_client.go -fake-name RestClient . restClient
+type restClient interface {
+	NewRequest(method, urlStr string, body interface{}) (*http.Request, error)
+	Do(ctx context.Context, req *http.Request, v interface{}) (*github.Response, error)
+}
+
 //counterfeiter:generate -o mocks/repositories_client.go -fake-name RepositoriesClient . repositoriesClient
 type repositoriesClient interface {
 	Get(ctx context.Context, owner, repo string) (*github.Repository, *github.Response, error)
 }
 
 type Config struct {
-	Org            string  `yaml:"org"`
-	AppID          int64   `yaml:"appID"`
-	InstallationID int64   `yaml:"installationID"`
-	PrivateKey     string  `yaml:"privateKey"`
-	Logging        Logging `yaml:"logging"`
-	Server         Server  `yaml:"server"`
+	Org                   string         `yaml:"org"`
+	AppID                 int64          `yaml:"appID"`
+	InstallationID        int64          `yaml:"installationID"`
+	Orgs                  []Organization `yaml:"orgs"`
+	DiscoverInstallations bool           `yaml:"discoverInstallations"`
+	PrivateKey            string         `yaml:"privateKey"`
+	Audit                 Audit          `yaml:"audit"`
+	GitHub                GitHub         `yaml:"github"`
+	Groups                Groups         `yaml:"groups"`
+	Janitor               Janitor        `yaml:"janitor"`
+	Logging               Logging        `yaml:"logging"`
+	OIDC                  OIDC           `yaml:"oidc"`
+	Policy                Policy         `yaml:"policy"`
+	Reconcile             Reconcile      `yaml:"reconcile"`
+	Server                Server         `yaml:"server"`
+	Webhook               Webhook        `yaml:"webhook"`
+}
+
+type Audit struct {
+	Sink string `yaml:"sink"`
+	Path string `yaml:"path"`
 }
 
 type Logging struct {
@@ -71,13 +113,39 @@ type Logging struct {
 	MaxSize      int    `yaml:"maxSize"`
 }
 
+type OIDC struct {
+	Enabled  bool         `yaml:"enabled"`
+	Issuer   string       `yaml:"issuer"`
+	Audience string       `yaml:"audience"`
+	JWKS     string       `yaml:"jwks"`
+	Policies []OIDCPolicy `yaml:"policies"`
+}
+
+type OIDCPolicy struct {
+	Org    string            `yaml:"org"`
+	Team   string            `yaml:"team"`
+	Role   string            `yaml:"role"`
+	Claims map[string]string `yaml:"claims"`
+}
+
 type Server struct {
 	Address   string  `yaml:"address"`
 	Port      int     `yaml:"port"`
 	RateLimit float64 `yaml:"rateLimit"`
+	Cache     Cache   `yaml:"cache"`
+	Retry     Retry   `yaml:"retry"`
 	TLS       TLS     `yaml:"tls"`
 }
 
+type Cache struct {
+	TTL         time.Duration `yaml:"ttl"`
+	NegativeTTL time.Duration `yaml:"negativeTTL"`
+}
+
+type Webhook struct {
+	Secret string `yaml:"secret"`
+}
+
 type TLS struct {
 	Enabled  bool   `yaml:"enabled"`
 	CertFile string `yaml:"certFile"`
@@ -87,21 +155,35 @@ type TLS struct {
 type Manager struct {
 	ActionsClient      actionsClient
 	RepositoriesClient repositoriesClient
+	RestClient         restClient
 	TeamsClient        teamsClient
+	TokenSource        tokenSource
 
-	Limit  *limiter.Limiter
-	Router *gin.Engine
-	Server *http.Server
+	AuditLog AuditSink
+	Cache    *MaintainershipCache
+	Offline  *OfflineRunners
+	OIDC     *OIDCVerifier
+	Limit    *limiter.Limiter
+	Metrics  *Metrics
+	Router   *gin.Engine
+	Server   *http.Server
 
 	Config *Config
 	Logger *logrus.Logger
 
+	// Organizations holds the managers of the organizations served under /api/v1/orgs/<name>, keyed on the lowercase
+	// organization name
+	Organizations map[string]*Manager
+	// defaultOrg is the organization of the manager the organization was added to, empty for that manager itself
+	defaultOrg string
+
 	CreateMaintainershipClient func(string, string) (*MaintainershipClient, *github.User, error)
 }
 
 type MaintainershipClient struct {
-	TeamsClient teamsClient
-	UsersClient usersClient
+	OrganizationsClient organizationsClient
+	TeamsClient         teamsClient
+	UsersClient         usersClient
 }
 
 func (m *Manager) Serve() {
@@ -115,13 +197,31 @@ func (m *Manager) Serve() {
 		syscall.SIGINT,
 		syscall.SIGTERM,
 		syscall.SIGQUIT)
+	ctx, cancel := context.WithCancel(context.Background())
 	go func() {
 		<-sigc
+		cancel()
 		err := m.Server.Shutdown(context.Background())
 		m.Logger.Errorf("Failed to shutdown server: %v", err)
+		if m.AuditLog != nil {
+			if err := m.AuditLog.Close(); err != nil {
+				m.Logger.Errorf("Failed to close audit log: %v", err)
+			}
+		}
 	}()
 	m.Logger.Debug("Configured OS signal handling")
 
+	for _, org := range m.managedOrganizations() {
+		if m.Config.Reconcile.Interval > 0 {
+			m.Logger.Infof("Starting runner group reconciler for organization %s with interval %s", org.Config.Org, m.Config.Reconcile.Interval)
+			go org.Reconciler(ctx)
+		}
+		if m.Config.Janitor.Interval > 0 {
+			m.Logger.Infof("Starting stale runner janitor for organization %s with interval %s", org.Config.Org, m.Config.Janitor.Interval)
+			go org.Janitor(ctx)
+		}
+	}
+
 	m.Logger.Debug("Compiling HTTP server address")
 	address := fmt.Sprintf("%s:%d", m.Config.Server.Address, m.Config.Server.Port)
 	m.Logger.Infof("Starting API server on address: %s", address)
@@ -139,31 +239,71 @@ func (m *Manager) Serve() {
 }
 
 func (m *Manager) SetRoutes() {
+	if m.Metrics != nil {
+		m.Router.Use(m.Metrics.Middleware)
+		m.Router.GET("/metrics", gin.WrapH(m.Metrics.Handler()))
+	}
+	m.Router.GET("/healthz", m.DoHealthz)
+	m.Router.GET("/readyz", m.DoReadyz)
 	v1 := m.Router.Group("/api/v1")
 	{
-		v1.POST("/group-create", LimitHandler(m.Limit), m.DoGroupCreate)
-		v1.DELETE("/group-delete", LimitHandler(m.Limit), m.DoGroupDelete)
-		v1.GET("/group-list", LimitHandler(m.Limit), m.DoGroupList)
-		v1.PATCH("/repos-add", LimitHandler(m.Limit), m.DoReposAdd)
-		v1.PATCH("/repos-remove", LimitHandler(m.Limit), m.DoReposRemove)
-		v1.PATCH("/repos-set", LimitHandler(m.Limit), m.DoReposSet)
-		v1.GET("/token-register", LimitHandler(m.Limit), m.DoTokenRegister)
-		v1.GET("/token-remove", LimitHandler(m.Limit), m.DoTokenRemove)
 		v1.GET("/status", LimitHandler(m.Limit), m.Status)
 	}
+	v2 := m.Router.Group("/api/v2")
+	// Without a default organization, routes are only served under the path of each organization
+	if m.Config.Org != "" || len(m.Organizations) == 0 {
+		m.setTeamRoutes(v1)
+		m.setV2Routes(v2)
+	}
+	for _, org := range m.managedOrganizations() {
+		org.setTeamRoutes(v1.Group("/orgs/" + org.Config.Org))
+		org.setV2Routes(v2.Group("/orgs/" + org.Config.Org))
+	}
+	if m.Config.Webhook.Secret != "" {
+		m.Router.POST("/webhooks/github", m.DoWebhook)
+	}
 	m.Logger.Debug("Initialized API endpoints")
 }
 
+// setTeamRoutes registers the API routes that act on the runner group of a team in the organization of the manager
+func (m *Manager) setTeamRoutes(group *gin.RouterGroup) {
+	teams := group.Group("", append([]gin.HandlerFunc{LimitHandler(m.Limit)}, m.AuthorizationHandlers()...)...)
+	{
+		teams.GET("/audit", m.RequirePermission(OperationAuditList), m.DoAuditList)
+		teams.POST("/group-create", m.RequirePermission(OperationGroupCreate), m.Audit, m.DoGroupCreate)
+		teams.DELETE("/group-delete", m.RequirePermission(OperationGroupDelete), m.Audit, m.DoGroupDelete)
+		teams.GET("/group-list", m.RequirePermission(OperationGroupList), m.DoGroupList)
+		teams.PATCH("/group-update", m.RequirePermission(OperationGroupUpdate), m.Audit, m.DoGroupUpdate)
+		teams.GET("/groups-list", m.RequirePermission(OperationGroupsList), m.DoGroupsList)
+		teams.GET("/janitor-report", m.RequirePermission(OperationJanitorReport), m.DoJanitorReport)
+		teams.PATCH("/labels-add", m.RequirePermission(OperationLabelsAdd), m.Audit, m.DoLabelsAdd)
+		teams.GET("/labels-list", m.RequirePermission(OperationLabelsList), m.DoLabelsList)
+		teams.PATCH("/labels-remove", m.RequirePermission(OperationLabelsRemove), m.Audit, m.DoLabelsRemove)
+		teams.PATCH("/labels-set", m.RequirePermission(OperationLabelsSet), m.Audit, m.DoLabelsSet)
+		teams.PATCH("/repos-add", m.RequirePermission(OperationReposAdd), m.Audit, m.DoReposAdd)
+		teams.PATCH("/repos-remove", m.RequirePermission(OperationReposRemove), m.Audit, m.DoReposRemove)
+		teams.PATCH("/repos-set", m.RequirePermission(OperationReposSet), m.Audit, m.DoReposSet)
+		teams.DELETE("/runner-delete", m.RequirePermission(OperationRunnerDelete), m.Audit, m.DoRunnerDelete)
+		teams.GET("/runner-list", m.RequirePermission(OperationRunnerList), m.DoRunnerList)
+		teams.POST("/runner-register", m.RequirePermission(OperationRunnerRegister), m.Audit, m.DoRunnerRegister)
+		// Organization-wide tokens can register a runner into any runner group, so they are not served when runners may
+		// only be registered through runner-register
+		if !m.Config.Policy.BrokeredRegistration {
+			teams.GET("/token-register", m.RequirePermission(OperationTokenRegister), m.Audit, m.DoTokenRegister)
+			teams.GET("/token-remove", m.RequirePermission(OperationTokenRemove), m.Audit, m.DoTokenRemove)
+		}
+		teams.GET("/workflows-list", m.RequirePermission(OperationWorkflowsList), m.DoWorkflowsList)
+		teams.PATCH("/workflows-set", m.RequirePermission(OperationWorkflowsSet), m.Audit, m.DoWorkflowsSet)
+	}
+}
+
 func (m *Manager) Status(c *gin.Context) {
 	uuid := requestid.Get(c)
 
 	m.Logger.WithField("uuid", uuid).Info("Retrieving Authorization header")
 	token := c.GetHeader("Authorization")
 	if token == "" {
-		c.JSON(http.StatusForbidden, &JSONResultError{
-			Code:  http.StatusForbidden,
-			Error: "Missing Authorization header",
-		})
+		writeError(c, http.StatusForbidden, ErrorCodeUnauthenticated, "Missing Authorization header")
 		return
 	}
 	m.Logger.WithField("uuid", uuid).Debug("Retrieved Authorization header")
@@ -179,7 +319,8 @@ func LimitHandler(lmt *limiter.Limiter) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		httpError := tollbooth.LimitByRequest(lmt, c.Writer, c.Request)
 		if httpError != nil {
-			c.Data(httpError.StatusCode, lmt.GetMessageContentType(), []byte(httpError.Message))
+			c.Set(rateLimitedKey, true)
+			writeError(c, httpError.StatusCode, ErrorCodeRateLimited, httpError.Message)
 			c.Abort()
 		} else {
 			c.Next()
@@ -187,28 +328,138 @@ func LimitHandler(lmt *limiter.Limiter) gin.HandlerFunc {
 	}
 }
 
-func (m *Manager) verifyMaintainership(token, team, uuid string) (bool, error) {
+// caller is the identity and policy roles resolved for the token of a request
+type caller struct {
+	login string
+	roles []string
+	// ancestry lists the teams walked from the requested team to the ancestor whose maintainers were granted access
+	ancestry []string
+	// teamNotFound records that the team could not be located for a caller without any role
+	teamNotFound bool
+}
+
+// resolveRoles returns the login of the caller and the policy roles they hold for the team
+func (m *Manager) resolveRoles(token, team, uuid string) (*caller, error) {
+	if cached, ok := m.Cache.Get(token, team); ok {
+		m.Logger.WithField("uuid", uuid).Debugf("Using cached roles for team %s", team)
+		if cached.teamNotFound {
+			return nil, teamNotFoundError(team)
+		}
+		return cached, nil
+	}
+
 	m.Logger.WithField("uuid", uuid).Info("Creating maintainership client")
 	client, user, err := m.CreateMaintainershipClient(token, uuid)
 	if err != nil {
-		return false, fmt.Errorf("failed retrieving user client: %w", err)
+		return nil, fmt.Errorf("failed retrieving user client: %w", asAPIError(err, http.StatusForbidden, ErrorCodeUnauthenticated))
 	}
 	m.Logger.WithField("uuid", uuid).Debug("Created maintainership client")
 
+	resolved := &caller{login: user.GetLogin()}
 	m.Logger.WithField("uuid", uuid).Infof("Retrieving team: %s", team)
-	membership, resp, err := client.TeamsClient.GetTeamMembershipBySlug(context.Background(), m.Config.Org, team, user.GetLogin())
+	role, teamNotFound, err := m.retrieveTeamRole(client, team, resolved.login)
 	if err != nil {
-		if resp != nil && resp.StatusCode == http.StatusNotFound {
-			return false, fmt.Errorf("unable to locate team %s", team)
-		}
-		return false, err
+		return nil, err
+	}
+	if role != "" {
+		resolved.roles = append(resolved.roles, role)
 	}
 	m.Logger.WithField("uuid", uuid).Debugf("Retrieved team %s", team)
 
-	return membership.GetRole() == "maintainer", nil
+	if m.Config.Policy.InheritMaintainership && role != RoleMaintainer {
+		m.Logger.WithField("uuid", uuid).Infof("Retrieving ancestors of team: %s", team)
+		ancestry, err := m.retrieveMaintainedAncestry(client, team, resolved.login)
+		if err != nil {
+			return nil, err
+		}
+		if ancestry != nil {
+			resolved.roles = append(resolved.roles, RoleMaintainer)
+			resolved.ancestry = ancestry
+			m.Logger.WithField("uuid", uuid).Infof("Inherited maintainership of team %s through ancestry %v", team, ancestry)
+		}
+		m.Logger.WithField("uuid", uuid).Debugf("Retrieved ancestors of team: %s", team)
+	}
+
+	if m.Config.Policy.Uses(RoleOwner) {
+		m.Logger.WithField("uuid", uuid).Info("Retrieving organization membership")
+		membership, resp, err := client.OrganizationsClient.GetOrgMembership(context.Background(), "", m.Config.Org)
+		if err != nil {
+			return nil, fmt.Errorf("unable to retrieve organization membership: %w", gitHubError(resp, err))
+		}
+		if membership.GetRole() == "admin" {
+			resolved.roles = append(resolved.roles, RoleOwner)
+		}
+		m.Logger.WithField("uuid", uuid).Debug("Retrieved organization membership")
+	}
+
+	// Callers without any role on a team they are not a member of are cached for the negative TTL, so repeated requests
+	// from non-members do not call the GitHub API
+	resolved.teamNotFound = teamNotFound && len(resolved.roles) == 0
+	m.Cache.Set(token, team, resolved)
+	if resolved.teamNotFound {
+		return nil, teamNotFoundError(team)
+	}
+	return resolved, nil
+}
+
+// teamNotFoundError reports a team that does not exist or that the caller is not a member of
+func teamNotFoundError(team string) error {
+	return newAPIError(http.StatusForbidden, ErrorCodeTeamNotFound, fmt.Errorf("unable to locate team %s", team))
+}
+
+// retrieveTeamRole returns the role the user holds on the team, which is empty if the user is not a member. GitHub does
+// not distinguish a missing team from a team the user is not a member of, so both are reported as not found.
+func (m *Manager) retrieveTeamRole(client *MaintainershipClient, team, user string) (string, bool, error) {
+	membership, resp, err := client.TeamsClient.GetTeamMembershipBySlug(context.Background(), m.Config.Org, team, user)
+	if err != nil {
+		if resp != nil && resp.Response != nil && resp.StatusCode == http.StatusNotFound {
+			return "", true, nil
+		}
+		return "", false, gitHubError(resp, err)
+	}
+	return membership.GetRole(), false, nil
+}
+
+// retrieveMaintainedAncestry walks the parent chain of the team and returns the teams from the team to the first
+// ancestor the user maintains, or nil if the user does not maintain any ancestor
+func (m *Manager) retrieveMaintainedAncestry(client *MaintainershipClient, team, user string) ([]string, error) {
+	ancestry := []string{team}
+	visited := map[string]bool{team: true}
+	slug := team
+	for {
+		current, resp, err := m.TeamsClient.GetTeamBySlug(context.Background(), m.Config.Org, slug)
+		if err != nil {
+			if resp != nil && resp.Response != nil && resp.StatusCode == http.StatusNotFound {
+				return nil, nil
+			}
+			return nil, fmt.Errorf("unable to retrieve team %s: %w", slug, gitHubError(resp, err))
+		}
+		parent := current.GetParent().GetSlug()
+		if parent == "" || visited[parent] {
+			return nil, nil
+		}
+		visited[parent] = true
+		ancestry = append(ancestry, parent)
+
+		role, _, err := m.retrieveTeamRole(client, parent, user)
+		if err != nil {
+			return nil, fmt.Errorf("unable to retrieve membership of team %s: %w", parent, err)
+		}
+		if role == RoleMaintainer {
+			return ancestry, nil
+		}
+		slug = parent
+	}
 }
 
-func (m *Manager) retrieveGroupID(name, uuid string) (*int64, int, error) {
+// retrieveGroupID returns the ID of the runner group of the team with the suffix, which is named with the configured
+// naming template, where the empty suffix selects the default runner group of the team
+func (m *Manager) retrieveGroupID(team, suffix, uuid string) (*int64, error) {
+	name, err := m.Config.Groups.GroupName(m.Config.Org, team, suffix)
+	if err != nil {
+		return nil, fmt.Errorf("unable to name runner group: %w", err)
+	}
+
 	ctx := context.Background()
 	m.Logger.WithField("uuid", uuid).Info("Retrieving runner groups")
 	var groups []*github.RunnerGroup
@@ -216,7 +467,7 @@ func (m *Manager) retrieveGroupID(name, uuid string) (*int64, int, error) {
 	for {
 		runnerGroups, resp, err := m.ActionsClient.ListOrganizationRunnerGroups(ctx, m.Config.Org, opts)
 		if err != nil {
-			return nil, resp.StatusCode, fmt.Errorf("failed querying organization runner groups: %w", err)
+			return nil, fmt.Errorf("failed querying organization runner groups: %w", gitHubError(resp, err))
 		}
 		groups = append(groups, runnerGroups.RunnerGroups...)
 		if resp.NextPage == 0 {
@@ -229,10 +480,13 @@ func (m *Manager) retrieveGroupID(name, uuid string) (*int64, int, error) {
 	m.Logger.WithField("uuid", uuid).Info("Searching for runner group")
 	for _, group := range groups {
 		if group.GetName() == name {
+			if err := m.Config.Groups.checkGroupOwner(m.Config.Org, name, team, suffix); err != nil {
+				return nil, err
+			}
 			m.Logger.WithField("uuid", uuid).Debug("Found runner group")
-			return group.ID, http.StatusOK, nil
+			return group.ID, nil
 		}
 	}
 
-	return nil, http.StatusNotFound, fmt.Errorf("unable to locate runner group with name %s", name)
+	return nil, newAPIError(http.StatusNotFound, ErrorCodeGroupNotFound, fmt.Errorf("unable to locate runner group with name %s", name))
 }
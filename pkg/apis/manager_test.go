@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/google/go-github/v41/github"
 	"github.com/lindluni/actions-runner-manager/pkg/apis/mocks"
@@ -45,7 +46,7 @@ func TestRetrieveGroupID_Success(t *testing.T) {
 			Config:        &Config{},
 			Logger:        logger,
 		}
-		id, _, err := manager.retrieveGroupID("fake-runner-group-name", "fake-uuid")
+		id, err := manager.retrieveGroupID("fake-runner-group-name", "", "fake-uuid")
 		require.NoError(t, err)
 		require.Equal(t, tc.expected, id)
 		require.Equal(t, client.ListOrganizationRunnerGroupsCallCount(), 2)
@@ -98,15 +99,15 @@ func TestRetrieveGroupID_Failure(t *testing.T) {
 			Config:        &Config{},
 			Logger:        logger,
 		}
-		id, statusCode, err := manager.retrieveGroupID("fake-runner-group-name", "fake-uuid")
+		id, err := manager.retrieveGroupID("fake-runner-group-name", "", "fake-uuid")
 		require.EqualError(t, err, tc.errString)
 		require.Nil(t, tc.expected, id)
-		require.Equal(t, statusCode, http.StatusNotFound)
+		require.Equal(t, http.StatusNotFound, asAPIError(err, http.StatusInternalServerError, ErrorCodeInternal).status)
 		require.Equal(t, client.ListOrganizationRunnerGroupsCallCount(), 1)
 	}
 }
 
-func TestVerifyMaintainership_Success(t *testing.T) {
+func TestResolveRoles_Success(t *testing.T) {
 	t.Parallel()
 
 	actionsClient := &mocks.ActionsClient{}
@@ -124,7 +125,77 @@ func TestVerifyMaintainership_Success(t *testing.T) {
 		},
 		Logger: logger,
 	}
-	isMaintainer, err := manager.verifyMaintainership("", "", "")
+	resolved, err := manager.resolveRoles("", "", "")
 	require.NoError(t, err)
-	require.False(t, isMaintainer)
+	require.Empty(t, resolved.login)
+	require.Empty(t, resolved.roles)
+}
+
+func TestResolveRoles_Cached(t *testing.T) {
+	t.Parallel()
+
+	teamsClient := &mocks.TeamsClient{}
+	teamsClient.GetTeamMembershipBySlugReturns(&github.Membership{Role: github.String("maintainer")}, nil, nil)
+	logger, _ := test.NewNullLogger()
+	clientCount := 0
+	manager := &Manager{
+		Cache:  NewMaintainershipCache(time.Minute, 0),
+		Config: &Config{},
+		CreateMaintainershipClient: func(string, string) (*MaintainershipClient, *github.User, error) {
+			clientCount++
+			return &MaintainershipClient{
+				TeamsClient: teamsClient,
+			}, &github.User{Login: github.String("fake-user")}, nil
+		},
+		Logger: logger,
+	}
+
+	for i := 0; i < 3; i++ {
+		resolved, err := manager.resolveRoles("fake-token", "fake-team", "fake-uuid")
+		require.NoError(t, err)
+		require.Equal(t, []string{RoleMaintainer}, resolved.roles)
+		require.Equal(t, "fake-user", resolved.login)
+	}
+	require.Equal(t, 1, clientCount)
+	require.Equal(t, 1, teamsClient.GetTeamMembershipBySlugCallCount())
+
+	manager.Cache.Invalidate("fake-team", "fake-user")
+	_, err := manager.resolveRoles("fake-token", "fake-team", "fake-uuid")
+	require.NoError(t, err)
+	require.Equal(t, 2, teamsClient.GetTeamMembershipBySlugCallCount())
+}
+
+func TestResolveRoles_NegativeCache(t *testing.T) {
+	t.Parallel()
+
+	teamsClient := &mocks.TeamsClient{}
+	teamsClient.GetTeamMembershipBySlugReturns(nil, &github.Response{Response: &http.Response{StatusCode: http.StatusNotFound}}, fmt.Errorf("not found"))
+	logger, _ := test.NewNullLogger()
+	now := time.Now()
+	manager := &Manager{
+		Cache:  NewMaintainershipCache(time.Minute, time.Second),
+		Config: &Config{Org: "fake-org"},
+		CreateMaintainershipClient: func(string, string) (*MaintainershipClient, *github.User, error) {
+			return &MaintainershipClient{
+				TeamsClient: teamsClient,
+			}, &github.User{Login: github.String("fake-user")}, nil
+		},
+		Logger: logger,
+	}
+	manager.Cache.now = func() time.Time { return now }
+
+	for i := 0; i < 3; i++ {
+		resolved, err := manager.resolveRoles("fake-token", "other-team", "fake-uuid")
+		require.EqualError(t, err, "unable to locate team other-team")
+		require.Nil(t, resolved)
+		apiErr := asAPIError(err, http.StatusInternalServerError, ErrorCodeInternal)
+		require.Equal(t, http.StatusForbidden, apiErr.status)
+		require.Equal(t, ErrorCodeTeamNotFound, apiErr.code)
+	}
+	require.Equal(t, 1, teamsClient.GetTeamMembershipBySlugCallCount())
+
+	now = now.Add(2 * time.Second)
+	_, err := manager.resolveRoles("fake-token", "other-team", "fake-uuid")
+	require.Error(t, err)
+	require.Equal(t, 2, teamsClient.GetTeamMembershipBySlugCallCount(), "negative results expire after the negative ttl")
 }
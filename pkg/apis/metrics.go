@@ -0,0 +1,178 @@
+/**
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package apis
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/go-github/v41/github"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const (
+	metricsNamespace = "actions_runner_manager"
+
+	rateLimitedKey = "rateLimited"
+
+	// TokenTypeRegistration labels registration tokens issued by the token-register API
+	TokenTypeRegistration = "registration"
+	// TokenTypeRemoval labels removal tokens issued by the token-remove API
+	TokenTypeRemoval = "removal"
+	// TokenTypeJIT labels just-in-time runner configurations issued by the runner-register API
+	TokenTypeJIT = "jit"
+)
+
+// Metrics holds the Prometheus collectors of the server. A nil Metrics discards every observation.
+type Metrics struct {
+	registry *prometheus.Registry
+
+	requests          *prometheus.CounterVec
+	requestDuration   *prometheus.HistogramVec
+	rateLimited       *prometheus.CounterVec
+	githubCalls       *prometheus.CounterVec
+	githubErrors      *prometheus.CounterVec
+	githubDuration    *prometheus.HistogramVec
+	githubRetries     *prometheus.CounterVec
+	githubRateLimit   *prometheus.GaugeVec
+	githubRateRemains *prometheus.GaugeVec
+	tokens            *prometheus.CounterVec
+}
+
+// NewMetrics creates the collectors of the server in a dedicated registry alongside the Go runtime and process collectors
+func NewMetrics() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "http_requests_total",
+			Help:      "Number of HTTP requests served, by route, method and status code.",
+		}, []string{"route", "method", "code"}),
+		requestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "Latency of HTTP requests, by route, method and status code.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"route", "method", "code"}),
+		rateLimited: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "rate_limited_requests_total",
+			Help:      "Number of HTTP requests rejected by the rate limiter, by route.",
+		}, []string{"route"}),
+		githubCalls: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "github_api_calls_total",
+			Help:      "Number of GitHub API calls made with the installation token, by client and method.",
+		}, []string{"client", "method"}),
+		githubErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "github_api_errors_total",
+			Help:      "Number of failed GitHub API calls made with the installation token, by client and method.",
+		}, []string{"client", "method"}),
+		githubDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "github_api_call_duration_seconds",
+			Help:      "Latency of GitHub API calls made with the installation token, by client and method.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"client", "method"}),
+		githubRetries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "github_api_retries_total",
+			Help:      "Number of GitHub API calls retried after a rate limit or transient error, by client and reason.",
+		}, []string{"client", "reason"}),
+		githubRateLimit: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "github_rate_limit",
+			Help:      "GitHub API rate limit of the installation token, by organization, as reported by the last call.",
+		}, []string{"org"}),
+		githubRateRemains: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "github_rate_limit_remaining",
+			Help:      "GitHub API requests remaining for the installation token, by organization, as reported by the last call.",
+		}, []string{"org"}),
+		tokens: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "runner_tokens_issued_total",
+			Help:      "Number of runner registration tokens, removal tokens and just-in-time configurations issued, by team and type.",
+		}, []string{"team", "type"}),
+	}
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.requests,
+		m.requestDuration,
+		m.rateLimited,
+		m.githubCalls,
+		m.githubErrors,
+		m.githubDuration,
+		m.githubRetries,
+		m.githubRateLimit,
+		m.githubRateRemains,
+		m.tokens,
+	)
+	return m
+}
+
+// Handler serves the collected metrics in the Prometheus exposition format
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+// Middleware records the count and latency of every request, and whether it was rejected by LimitHandler
+func (m *Metrics) Middleware(c *gin.Context) {
+	if m == nil {
+		return
+	}
+	start := time.Now()
+	c.Next()
+
+	route := c.FullPath()
+	if route == "" {
+		route = "unmatched"
+	}
+	code := strconv.Itoa(c.Writer.Status())
+	m.requests.WithLabelValues(route, c.Request.Method, code).Inc()
+	m.requestDuration.WithLabelValues(route, c.Request.Method, code).Observe(time.Since(start).Seconds())
+	if c.GetBool(rateLimitedKey) {
+		m.rateLimited.WithLabelValues(route).Inc()
+	}
+}
+
+// ObserveGitHubCall records the outcome and latency of a GitHub API call, and the rate limit reported in its response for
+// the installation token of the organization
+func (m *Metrics) ObserveGitHubCall(org, client, method string, start time.Time, resp *github.Response, err error) {
+	if m == nil {
+		return
+	}
+	m.githubCalls.WithLabelValues(client, method).Inc()
+	m.githubDuration.WithLabelValues(client, method).Observe(time.Since(start).Seconds())
+	if err != nil {
+		m.githubErrors.WithLabelValues(client, method).Inc()
+	}
+	if resp != nil && resp.Rate.Limit > 0 {
+		m.githubRateLimit.WithLabelValues(org).Set(float64(resp.Rate.Limit))
+		m.githubRateRemains.WithLabelValues(org).Set(float64(resp.Rate.Remaining))
+	}
+}
+
+// RetriedGitHubCall records a GitHub API call retried by the RetryTransport of the client
+func (m *Metrics) RetriedGitHubCall(client, reason string) {
+	if m == nil {
+		return
+	}
+	m.githubRetries.WithLabelValues(client, reason).Inc()
+}
+
+// TokenIssued records a runner token or configuration issued to a team
+func (m *Metrics) TokenIssued(team, tokenType string) {
+	if m == nil {
+		return
+	}
+	m.tokens.WithLabelValues(team, tokenType).Inc()
+}
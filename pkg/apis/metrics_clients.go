@@ -0,0 +1,152 @@
+/**
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package apis
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/go-github/v41/github"
+)
+
+// InstrumentedActionsClient records metrics for every call made through the wrapped actionsClient
+type InstrumentedActionsClient struct {
+	Client  actionsClient
+	Metrics *Metrics
+	Org     string
+}
+
+func (c *InstrumentedActionsClient) AddRepositoryAccessRunnerGroup(ctx context.Context, org string, groupID, repoID int64) (*github.Response, error) {
+	start := time.Now()
+	resp, err := c.Client.AddRepositoryAccessRunnerGroup(ctx, org, groupID, repoID)
+	c.Metrics.ObserveGitHubCall(c.Org, "actions", "AddRepositoryAccessRunnerGroup", start, resp, err)
+	return resp, err
+}
+
+func (c *InstrumentedActionsClient) CreateOrganizationRegistrationToken(ctx context.Context, owner string) (*github.RegistrationToken, *github.Response, error) {
+	start := time.Now()
+	result, resp, err := c.Client.CreateOrganizationRegistrationToken(ctx, owner)
+	c.Metrics.ObserveGitHubCall(c.Org, "actions", "CreateOrganizationRegistrationToken", start, resp, err)
+	return result, resp, err
+}
+
+func (c *InstrumentedActionsClient) CreateOrganizationRemoveToken(ctx context.Context, owner string) (*github.RemoveToken, *github.Response, error) {
+	start := time.Now()
+	result, resp, err := c.Client.CreateOrganizationRemoveToken(ctx, owner)
+	c.Metrics.ObserveGitHubCall(c.Org, "actions", "CreateOrganizationRemoveToken", start, resp, err)
+	return result, resp, err
+}
+
+func (c *InstrumentedActionsClient) CreateOrganizationRunnerGroup(ctx context.Context, org string, createReq github.CreateRunnerGroupRequest) (*github.RunnerGroup, *github.Response, error) {
+	start := time.Now()
+	result, resp, err := c.Client.CreateOrganizationRunnerGroup(ctx, org, createReq)
+	c.Metrics.ObserveGitHubCall(c.Org, "actions", "CreateOrganizationRunnerGroup", start, resp, err)
+	return result, resp, err
+}
+
+func (c *InstrumentedActionsClient) DeleteOrganizationRunnerGroup(ctx context.Context, org string, groupID int64) (*github.Response, error) {
+	start := time.Now()
+	resp, err := c.Client.DeleteOrganizationRunnerGroup(ctx, org, groupID)
+	c.Metrics.ObserveGitHubCall(c.Org, "actions", "DeleteOrganizationRunnerGroup", start, resp, err)
+	return resp, err
+}
+
+func (c *InstrumentedActionsClient) GetOrganizationRunner(ctx context.Context, owner string, runnerID int64) (*github.Runner, *github.Response, error) {
+	start := time.Now()
+	result, resp, err := c.Client.GetOrganizationRunner(ctx, owner, runnerID)
+	c.Metrics.ObserveGitHubCall(c.Org, "actions", "GetOrganizationRunner", start, resp, err)
+	return result, resp, err
+}
+
+func (c *InstrumentedActionsClient) ListOrganizationRunnerGroups(ctx context.Context, org string, opts *github.ListOptions) (*github.RunnerGroups, *github.Response, error) {
+	start := time.Now()
+	result, resp, err := c.Client.ListOrganizationRunnerGroups(ctx, org, opts)
+	c.Metrics.ObserveGitHubCall(c.Org, "actions", "ListOrganizationRunnerGroups", start, resp, err)
+	return result, resp, err
+}
+
+func (c *InstrumentedActionsClient) ListRepositoryAccessRunnerGroup(ctx context.Context, org string, groupID int64, opts *github.ListOptions) (*github.ListRepositories, *github.Response, error) {
+	start := time.Now()
+	result, resp, err := c.Client.ListRepositoryAccessRunnerGroup(ctx, org, groupID, opts)
+	c.Metrics.ObserveGitHubCall(c.Org, "actions", "ListRepositoryAccessRunnerGroup", start, resp, err)
+	return result, resp, err
+}
+
+func (c *InstrumentedActionsClient) ListRunnerGroupRunners(ctx context.Context, org string, groupID int64, opts *github.ListOptions) (*github.Runners, *github.Response, error) {
+	start := time.Now()
+	result, resp, err := c.Client.ListRunnerGroupRunners(ctx, org, groupID, opts)
+	c.Metrics.ObserveGitHubCall(c.Org, "actions", "ListRunnerGroupRunners", start, resp, err)
+	return result, resp, err
+}
+
+func (c *InstrumentedActionsClient) RemoveOrganizationRunner(ctx context.Context, owner string, runnerID int64) (*github.Response, error) {
+	start := time.Now()
+	resp, err := c.Client.RemoveOrganizationRunner(ctx, owner, runnerID)
+	c.Metrics.ObserveGitHubCall(c.Org, "actions", "RemoveOrganizationRunner", start, resp, err)
+	return resp, err
+}
+
+func (c *InstrumentedActionsClient) RemoveRepositoryAccessRunnerGroup(ctx context.Context, org string, groupID, repoID int64) (*github.Response, error) {
+	start := time.Now()
+	resp, err := c.Client.RemoveRepositoryAccessRunnerGroup(ctx, org, groupID, repoID)
+	c.Metrics.ObserveGitHubCall(c.Org, "actions", "RemoveRepositoryAccessRunnerGroup", start, resp, err)
+	return resp, err
+}
+
+func (c *InstrumentedActionsClient) SetRepositoryAccessRunnerGroup(ctx context.Context, org string, groupID int64, ids github.SetRepoAccessRunnerGroupRequest) (*github.Response, error) {
+	start := time.Now()
+	resp, err := c.Client.SetRepositoryAccessRunnerGroup(ctx, org, groupID, ids)
+	c.Metrics.ObserveGitHubCall(c.Org, "actions", "SetRepositoryAccessRunnerGroup", start, resp, err)
+	return resp, err
+}
+
+func (c *InstrumentedActionsClient) UpdateOrganizationRunnerGroup(ctx context.Context, org string, groupID int64, updateReq github.UpdateRunnerGroupRequest) (*github.RunnerGroup, *github.Response, error) {
+	start := time.Now()
+	group, resp, err := c.Client.UpdateOrganizationRunnerGroup(ctx, org, groupID, updateReq)
+	c.Metrics.ObserveGitHubCall(c.Org, "actions", "UpdateOrganizationRunnerGroup", start, resp, err)
+	return group, resp, err
+}
+
+// InstrumentedRepositoriesClient records metrics for every call made through the wrapped repositoriesClient
+type InstrumentedRepositoriesClient struct {
+	Client  repositoriesClient
+	Metrics *Metrics
+	Org     string
+}
+
+func (c *InstrumentedRepositoriesClient) Get(ctx context.Context, owner, repo string) (*github.Repository, *github.Response, error) {
+	start := time.Now()
+	result, resp, err := c.Client.Get(ctx, owner, repo)
+	c.Metrics.ObserveGitHubCall(c.Org, "repositories", "Get", start, resp, err)
+	return result, resp, err
+}
+
+// InstrumentedTeamsClient records metrics for every call made through the wrapped teamsClient
+type InstrumentedTeamsClient struct {
+	Client  teamsClient
+	Metrics *Metrics
+	Org     string
+}
+
+func (c *InstrumentedTeamsClient) GetTeamMembershipBySlug(ctx context.Context, org, slug, user string) (*github.Membership, *github.Response, error) {
+	start := time.Now()
+	result, resp, err := c.Client.GetTeamMembershipBySlug(ctx, org, slug, user)
+	c.Metrics.ObserveGitHubCall(c.Org, "teams", "GetTeamMembershipBySlug", start, resp, err)
+	return result, resp, err
+}
+
+func (c *InstrumentedTeamsClient) GetTeamBySlug(ctx context.Context, org, slug string) (*github.Team, *github.Response, error) {
+	start := time.Now()
+	result, resp, err := c.Client.GetTeamBySlug(ctx, org, slug)
+	c.Metrics.ObserveGitHubCall(c.Org, "teams", "GetTeamBySlug", start, resp, err)
+	return result, resp, err
+}
+
+func (c *InstrumentedTeamsClient) ListTeamReposBySlug(ctx context.Context, org, slug string, opts *github.ListOptions) ([]*github.Repository, *github.Response, error) {
+	start := time.Now()
+	result, resp, err := c.Client.ListTeamReposBySlug(ctx, org, slug, opts)
+	c.Metrics.ObserveGitHubCall(c.Org, "teams", "ListTeamReposBySlug", start, resp, err)
+	return result, resp, err
+}
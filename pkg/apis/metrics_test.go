@@ -0,0 +1,111 @@
+/**
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package apis
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/didip/tollbooth/v6"
+	"github.com/gin-gonic/gin"
+	"github.com/google/go-github/v41/github"
+	"github.com/lindluni/actions-runner-manager/pkg/apis/mocks"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetrics_Requests(t *testing.T) {
+	t.Parallel()
+
+	metrics := NewMetrics()
+	actionsClient := &mocks.ActionsClient{}
+	actionsClient.CreateOrganizationRegistrationTokenReturns(&github.RegistrationToken{Token: github.String("fake-token")}, nil, nil)
+	teamsClient := &mocks.TeamsClient{}
+	teamsClient.GetTeamMembershipBySlugReturns(&github.Membership{Role: github.String("maintainer")}, nil, nil)
+	logger, _ := test.NewNullLogger()
+	manager := &Manager{
+		ActionsClient: &InstrumentedActionsClient{Client: actionsClient, Metrics: metrics},
+		Config:        &Config{Org: "fake-org"},
+		CreateMaintainershipClient: func(string, string) (*MaintainershipClient, *github.User, error) {
+			return &MaintainershipClient{
+				TeamsClient: teamsClient,
+			}, &github.User{Login: github.String("fake-user")}, nil
+		},
+		Limit:   tollbooth.NewLimiter(1, nil),
+		Logger:  logger,
+		Metrics: metrics,
+		Router:  gin.New(),
+	}
+	manager.SetRoutes()
+
+	for i := 0; i < 2; i++ {
+		writer := httptest.NewRecorder()
+		request, err := http.NewRequest(http.MethodGet, "/api/v1/token-register?team=fake-team", nil)
+		require.NoError(t, err)
+		request.Header.Set("Authorization", "test-token")
+		request.RemoteAddr = "127.0.0.1:8080"
+		manager.Router.ServeHTTP(writer, request)
+	}
+	writer := httptest.NewRecorder()
+	request, err := http.NewRequest(http.MethodGet, "/api/v1/unknown", nil)
+	require.NoError(t, err)
+	manager.Router.ServeHTTP(writer, request)
+
+	require.Equal(t, float64(1), testutil.ToFloat64(metrics.requests.WithLabelValues("/api/v1/token-register", http.MethodGet, "200")))
+	require.Equal(t, float64(1), testutil.ToFloat64(metrics.requests.WithLabelValues("/api/v1/token-register", http.MethodGet, "429")))
+	require.Equal(t, float64(1), testutil.ToFloat64(metrics.requests.WithLabelValues("unmatched", http.MethodGet, "404")))
+	require.Equal(t, float64(1), testutil.ToFloat64(metrics.rateLimited.WithLabelValues("/api/v1/token-register")))
+	require.Equal(t, float64(1), testutil.ToFloat64(metrics.tokens.WithLabelValues("fake-team", TokenTypeRegistration)))
+	require.Equal(t, float64(1), testutil.ToFloat64(metrics.githubCalls.WithLabelValues("actions", "CreateOrganizationRegistrationToken")))
+
+	writer = httptest.NewRecorder()
+	request, err = http.NewRequest(http.MethodGet, "/metrics", nil)
+	require.NoError(t, err)
+	manager.Router.ServeHTTP(writer, request)
+	result := writer.Result()
+	body, err := ioutil.ReadAll(result.Body)
+	defer result.Body.Close()
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, result.StatusCode)
+	require.Contains(t, string(body), `actions_runner_manager_http_request_duration_seconds_count{code="200",method="GET",route="/api/v1/token-register"} 1`)
+	require.Contains(t, string(body), `actions_runner_manager_runner_tokens_issued_total{team="fake-team",type="registration"} 1`)
+}
+
+func TestMetrics_GitHubCalls(t *testing.T) {
+	t.Parallel()
+
+	metrics := NewMetrics()
+	teamsClient := &mocks.TeamsClient{}
+	teamsClient.GetTeamBySlugReturnsOnCall(0, &github.Team{}, &github.Response{Rate: github.Rate{Limit: 5000, Remaining: 4999}}, nil)
+	teamsClient.GetTeamBySlugReturnsOnCall(1, nil, nil, fmt.Errorf("fake-error"))
+	client := &InstrumentedTeamsClient{Client: teamsClient, Metrics: metrics, Org: "fake-org"}
+
+	_, _, err := client.GetTeamBySlug(context.Background(), "fake-org", "fake-team")
+	require.NoError(t, err)
+	_, _, err = client.GetTeamBySlug(context.Background(), "fake-org", "fake-team")
+	require.EqualError(t, err, "fake-error")
+
+	require.Equal(t, float64(2), testutil.ToFloat64(metrics.githubCalls.WithLabelValues("teams", "GetTeamBySlug")))
+	require.Equal(t, float64(1), testutil.ToFloat64(metrics.githubErrors.WithLabelValues("teams", "GetTeamBySlug")))
+	require.Equal(t, float64(5000), testutil.ToFloat64(metrics.githubRateLimit.WithLabelValues("fake-org")))
+	require.Equal(t, float64(4999), testutil.ToFloat64(metrics.githubRateRemains.WithLabelValues("fake-org")))
+
+	otherClient := &InstrumentedTeamsClient{Client: teamsClient, Metrics: metrics, Org: "other-org"}
+	teamsClient.GetTeamBySlugReturnsOnCall(2, &github.Team{}, &github.Response{Rate: github.Rate{Limit: 15000, Remaining: 10}}, nil)
+	_, _, err = otherClient.GetTeamBySlug(context.Background(), "other-org", "fake-team")
+	require.NoError(t, err)
+	require.Equal(t, float64(4999), testutil.ToFloat64(metrics.githubRateRemains.WithLabelValues("fake-org")))
+	require.Equal(t, float64(10), testutil.ToFloat64(metrics.githubRateRemains.WithLabelValues("other-org")))
+
+	var nilMetrics *Metrics
+	nilMetrics.TokenIssued("fake-team", TokenTypeRemoval)
+	nilMetrics.ObserveGitHubCall("fake-org", "teams", "GetTeamBySlug", time.Now(), nil, nil)
+}
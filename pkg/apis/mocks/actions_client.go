@@ -89,6 +89,23 @@ type ActionsClient struct {
 		result1 *github.Response
 		result2 error
 	}
+	GetOrganizationRunnerStub        func(context.Context, string, int64) (*github.Runner, *github.Response, error)
+	getOrganizationRunnerMutex       sync.RWMutex
+	getOrganizationRunnerArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 int64
+	}
+	getOrganizationRunnerReturns struct {
+		result1 *github.Runner
+		result2 *github.Response
+		result3 error
+	}
+	getOrganizationRunnerReturnsOnCall map[int]struct {
+		result1 *github.Runner
+		result2 *github.Response
+		result3 error
+	}
 	ListOrganizationRunnerGroupsStub        func(context.Context, string, *github.ListOptions) (*github.RunnerGroups, *github.Response, error)
 	listOrganizationRunnerGroupsMutex       sync.RWMutex
 	listOrganizationRunnerGroupsArgsForCall []struct {
@@ -142,6 +159,21 @@ type ActionsClient struct {
 		result2 *github.Response
 		result3 error
 	}
+	RemoveOrganizationRunnerStub        func(context.Context, string, int64) (*github.Response, error)
+	removeOrganizationRunnerMutex       sync.RWMutex
+	removeOrganizationRunnerArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 int64
+	}
+	removeOrganizationRunnerReturns struct {
+		result1 *github.Response
+		result2 error
+	}
+	removeOrganizationRunnerReturnsOnCall map[int]struct {
+		result1 *github.Response
+		result2 error
+	}
 	RemoveRepositoryAccessRunnerGroupStub        func(context.Context, string, int64, int64) (*github.Response, error)
 	removeRepositoryAccessRunnerGroupMutex       sync.RWMutex
 	removeRepositoryAccessRunnerGroupArgsForCall []struct {
@@ -174,6 +206,24 @@ type ActionsClient struct {
 		result1 *github.Response
 		result2 error
 	}
+	UpdateOrganizationRunnerGroupStub        func(context.Context, string, int64, github.UpdateRunnerGroupRequest) (*github.RunnerGroup, *github.Response, error)
+	updateOrganizationRunnerGroupMutex       sync.RWMutex
+	updateOrganizationRunnerGroupArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 int64
+		arg4 github.UpdateRunnerGroupRequest
+	}
+	updateOrganizationRunnerGroupReturns struct {
+		result1 *github.RunnerGroup
+		result2 *github.Response
+		result3 error
+	}
+	updateOrganizationRunnerGroupReturnsOnCall map[int]struct {
+		result1 *github.RunnerGroup
+		result2 *github.Response
+		result3 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -516,6 +566,75 @@ func (fake *ActionsClient) DeleteOrganizationRunnerGroupReturnsOnCall(i int, res
 	}{result1, result2}
 }
 
+func (fake *ActionsClient) GetOrganizationRunner(arg1 context.Context, arg2 string, arg3 int64) (*github.Runner, *github.Response, error) {
+	fake.getOrganizationRunnerMutex.Lock()
+	ret, specificReturn := fake.getOrganizationRunnerReturnsOnCall[len(fake.getOrganizationRunnerArgsForCall)]
+	fake.getOrganizationRunnerArgsForCall = append(fake.getOrganizationRunnerArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 int64
+	}{arg1, arg2, arg3})
+	stub := fake.GetOrganizationRunnerStub
+	fakeReturns := fake.getOrganizationRunnerReturns
+	fake.recordInvocation("GetOrganizationRunner", []interface{}{arg1, arg2, arg3})
+	fake.getOrganizationRunnerMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *ActionsClient) GetOrganizationRunnerCallCount() int {
+	fake.getOrganizationRunnerMutex.RLock()
+	defer fake.getOrganizationRunnerMutex.RUnlock()
+	return len(fake.getOrganizationRunnerArgsForCall)
+}
+
+func (fake *ActionsClient) GetOrganizationRunnerCalls(stub func(context.Context, string, int64) (*github.Runner, *github.Response, error)) {
+	fake.getOrganizationRunnerMutex.Lock()
+	defer fake.getOrganizationRunnerMutex.Unlock()
+	fake.GetOrganizationRunnerStub = stub
+}
+
+func (fake *ActionsClient) GetOrganizationRunnerArgsForCall(i int) (context.Context, string, int64) {
+	fake.getOrganizationRunnerMutex.RLock()
+	defer fake.getOrganizationRunnerMutex.RUnlock()
+	argsForCall := fake.getOrganizationRunnerArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *ActionsClient) GetOrganizationRunnerReturns(result1 *github.Runner, result2 *github.Response, result3 error) {
+	fake.getOrganizationRunnerMutex.Lock()
+	defer fake.getOrganizationRunnerMutex.Unlock()
+	fake.GetOrganizationRunnerStub = nil
+	fake.getOrganizationRunnerReturns = struct {
+		result1 *github.Runner
+		result2 *github.Response
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *ActionsClient) GetOrganizationRunnerReturnsOnCall(i int, result1 *github.Runner, result2 *github.Response, result3 error) {
+	fake.getOrganizationRunnerMutex.Lock()
+	defer fake.getOrganizationRunnerMutex.Unlock()
+	fake.GetOrganizationRunnerStub = nil
+	if fake.getOrganizationRunnerReturnsOnCall == nil {
+		fake.getOrganizationRunnerReturnsOnCall = make(map[int]struct {
+			result1 *github.Runner
+			result2 *github.Response
+			result3 error
+		})
+	}
+	fake.getOrganizationRunnerReturnsOnCall[i] = struct {
+		result1 *github.Runner
+		result2 *github.Response
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *ActionsClient) ListOrganizationRunnerGroups(arg1 context.Context, arg2 string, arg3 *github.ListOptions) (*github.RunnerGroups, *github.Response, error) {
 	fake.listOrganizationRunnerGroupsMutex.Lock()
 	ret, specificReturn := fake.listOrganizationRunnerGroupsReturnsOnCall[len(fake.listOrganizationRunnerGroupsArgsForCall)]
@@ -725,6 +844,72 @@ func (fake *ActionsClient) ListRunnerGroupRunnersReturnsOnCall(i int, result1 *g
 	}{result1, result2, result3}
 }
 
+func (fake *ActionsClient) RemoveOrganizationRunner(arg1 context.Context, arg2 string, arg3 int64) (*github.Response, error) {
+	fake.removeOrganizationRunnerMutex.Lock()
+	ret, specificReturn := fake.removeOrganizationRunnerReturnsOnCall[len(fake.removeOrganizationRunnerArgsForCall)]
+	fake.removeOrganizationRunnerArgsForCall = append(fake.removeOrganizationRunnerArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 int64
+	}{arg1, arg2, arg3})
+	stub := fake.RemoveOrganizationRunnerStub
+	fakeReturns := fake.removeOrganizationRunnerReturns
+	fake.recordInvocation("RemoveOrganizationRunner", []interface{}{arg1, arg2, arg3})
+	fake.removeOrganizationRunnerMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ActionsClient) RemoveOrganizationRunnerCallCount() int {
+	fake.removeOrganizationRunnerMutex.RLock()
+	defer fake.removeOrganizationRunnerMutex.RUnlock()
+	return len(fake.removeOrganizationRunnerArgsForCall)
+}
+
+func (fake *ActionsClient) RemoveOrganizationRunnerCalls(stub func(context.Context, string, int64) (*github.Response, error)) {
+	fake.removeOrganizationRunnerMutex.Lock()
+	defer fake.removeOrganizationRunnerMutex.Unlock()
+	fake.RemoveOrganizationRunnerStub = stub
+}
+
+func (fake *ActionsClient) RemoveOrganizationRunnerArgsForCall(i int) (context.Context, string, int64) {
+	fake.removeOrganizationRunnerMutex.RLock()
+	defer fake.removeOrganizationRunnerMutex.RUnlock()
+	argsForCall := fake.removeOrganizationRunnerArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *ActionsClient) RemoveOrganizationRunnerReturns(result1 *github.Response, result2 error) {
+	fake.removeOrganizationRunnerMutex.Lock()
+	defer fake.removeOrganizationRunnerMutex.Unlock()
+	fake.RemoveOrganizationRunnerStub = nil
+	fake.removeOrganizationRunnerReturns = struct {
+		result1 *github.Response
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ActionsClient) RemoveOrganizationRunnerReturnsOnCall(i int, result1 *github.Response, result2 error) {
+	fake.removeOrganizationRunnerMutex.Lock()
+	defer fake.removeOrganizationRunnerMutex.Unlock()
+	fake.RemoveOrganizationRunnerStub = nil
+	if fake.removeOrganizationRunnerReturnsOnCall == nil {
+		fake.removeOrganizationRunnerReturnsOnCall = make(map[int]struct {
+			result1 *github.Response
+			result2 error
+		})
+	}
+	fake.removeOrganizationRunnerReturnsOnCall[i] = struct {
+		result1 *github.Response
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *ActionsClient) RemoveRepositoryAccessRunnerGroup(arg1 context.Context, arg2 string, arg3 int64, arg4 int64) (*github.Response, error) {
 	fake.removeRepositoryAccessRunnerGroupMutex.Lock()
 	ret, specificReturn := fake.removeRepositoryAccessRunnerGroupReturnsOnCall[len(fake.removeRepositoryAccessRunnerGroupArgsForCall)]
@@ -859,6 +1044,76 @@ func (fake *ActionsClient) SetRepositoryAccessRunnerGroupReturnsOnCall(i int, re
 	}{result1, result2}
 }
 
+func (fake *ActionsClient) UpdateOrganizationRunnerGroup(arg1 context.Context, arg2 string, arg3 int64, arg4 github.UpdateRunnerGroupRequest) (*github.RunnerGroup, *github.Response, error) {
+	fake.updateOrganizationRunnerGroupMutex.Lock()
+	ret, specificReturn := fake.updateOrganizationRunnerGroupReturnsOnCall[len(fake.updateOrganizationRunnerGroupArgsForCall)]
+	fake.updateOrganizationRunnerGroupArgsForCall = append(fake.updateOrganizationRunnerGroupArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 int64
+		arg4 github.UpdateRunnerGroupRequest
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.UpdateOrganizationRunnerGroupStub
+	fakeReturns := fake.updateOrganizationRunnerGroupReturns
+	fake.recordInvocation("UpdateOrganizationRunnerGroup", []interface{}{arg1, arg2, arg3, arg4})
+	fake.updateOrganizationRunnerGroupMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *ActionsClient) UpdateOrganizationRunnerGroupCallCount() int {
+	fake.updateOrganizationRunnerGroupMutex.RLock()
+	defer fake.updateOrganizationRunnerGroupMutex.RUnlock()
+	return len(fake.updateOrganizationRunnerGroupArgsForCall)
+}
+
+func (fake *ActionsClient) UpdateOrganizationRunnerGroupCalls(stub func(context.Context, string, int64, github.UpdateRunnerGroupRequest) (*github.RunnerGroup, *github.Response, error)) {
+	fake.updateOrganizationRunnerGroupMutex.Lock()
+	defer fake.updateOrganizationRunnerGroupMutex.Unlock()
+	fake.UpdateOrganizationRunnerGroupStub = stub
+}
+
+func (fake *ActionsClient) UpdateOrganizationRunnerGroupArgsForCall(i int) (context.Context, string, int64, github.UpdateRunnerGroupRequest) {
+	fake.updateOrganizationRunnerGroupMutex.RLock()
+	defer fake.updateOrganizationRunnerGroupMutex.RUnlock()
+	argsForCall := fake.updateOrganizationRunnerGroupArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *ActionsClient) UpdateOrganizationRunnerGroupReturns(result1 *github.RunnerGroup, result2 *github.Response, result3 error) {
+	fake.updateOrganizationRunnerGroupMutex.Lock()
+	defer fake.updateOrganizationRunnerGroupMutex.Unlock()
+	fake.UpdateOrganizationRunnerGroupStub = nil
+	fake.updateOrganizationRunnerGroupReturns = struct {
+		result1 *github.RunnerGroup
+		result2 *github.Response
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *ActionsClient) UpdateOrganizationRunnerGroupReturnsOnCall(i int, result1 *github.RunnerGroup, result2 *github.Response, result3 error) {
+	fake.updateOrganizationRunnerGroupMutex.Lock()
+	defer fake.updateOrganizationRunnerGroupMutex.Unlock()
+	fake.UpdateOrganizationRunnerGroupStub = nil
+	if fake.updateOrganizationRunnerGroupReturnsOnCall == nil {
+		fake.updateOrganizationRunnerGroupReturnsOnCall = make(map[int]struct {
+			result1 *github.RunnerGroup
+			result2 *github.Response
+			result3 error
+		})
+	}
+	fake.updateOrganizationRunnerGroupReturnsOnCall[i] = struct {
+		result1 *github.RunnerGroup
+		result2 *github.Response
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *ActionsClient) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -872,16 +1127,22 @@ func (fake *ActionsClient) Invocations() map[string][][]interface{} {
 	defer fake.createOrganizationRunnerGroupMutex.RUnlock()
 	fake.deleteOrganizationRunnerGroupMutex.RLock()
 	defer fake.deleteOrganizationRunnerGroupMutex.RUnlock()
+	fake.getOrganizationRunnerMutex.RLock()
+	defer fake.getOrganizationRunnerMutex.RUnlock()
 	fake.listOrganizationRunnerGroupsMutex.RLock()
 	defer fake.listOrganizationRunnerGroupsMutex.RUnlock()
 	fake.listRepositoryAccessRunnerGroupMutex.RLock()
 	defer fake.listRepositoryAccessRunnerGroupMutex.RUnlock()
 	fake.listRunnerGroupRunnersMutex.RLock()
 	defer fake.listRunnerGroupRunnersMutex.RUnlock()
+	fake.removeOrganizationRunnerMutex.RLock()
+	defer fake.removeOrganizationRunnerMutex.RUnlock()
 	fake.removeRepositoryAccessRunnerGroupMutex.RLock()
 	defer fake.removeRepositoryAccessRunnerGroupMutex.RUnlock()
 	fake.setRepositoryAccessRunnerGroupMutex.RLock()
 	defer fake.setRepositoryAccessRunnerGroupMutex.RUnlock()
+	fake.updateOrganizationRunnerGroupMutex.RLock()
+	defer fake.updateOrganizationRunnerGroupMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
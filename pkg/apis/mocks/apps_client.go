@@ -0,0 +1,208 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package mocks
+
+import (
+	"context"
+	"sync"
+
+	"github.com/google/go-github/v41/github"
+)
+
+type AppsClient struct {
+	FindOrganizationInstallationStub        func(context.Context, string) (*github.Installation, *github.Response, error)
+	findOrganizationInstallationMutex       sync.RWMutex
+	findOrganizationInstallationArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	findOrganizationInstallationReturns struct {
+		result1 *github.Installation
+		result2 *github.Response
+		result3 error
+	}
+	findOrganizationInstallationReturnsOnCall map[int]struct {
+		result1 *github.Installation
+		result2 *github.Response
+		result3 error
+	}
+	ListInstallationsStub        func(context.Context, *github.ListOptions) ([]*github.Installation, *github.Response, error)
+	listInstallationsMutex       sync.RWMutex
+	listInstallationsArgsForCall []struct {
+		arg1 context.Context
+		arg2 *github.ListOptions
+	}
+	listInstallationsReturns struct {
+		result1 []*github.Installation
+		result2 *github.Response
+		result3 error
+	}
+	listInstallationsReturnsOnCall map[int]struct {
+		result1 []*github.Installation
+		result2 *github.Response
+		result3 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *AppsClient) FindOrganizationInstallation(arg1 context.Context, arg2 string) (*github.Installation, *github.Response, error) {
+	fake.findOrganizationInstallationMutex.Lock()
+	ret, specificReturn := fake.findOrganizationInstallationReturnsOnCall[len(fake.findOrganizationInstallationArgsForCall)]
+	fake.findOrganizationInstallationArgsForCall = append(fake.findOrganizationInstallationArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.FindOrganizationInstallationStub
+	fakeReturns := fake.findOrganizationInstallationReturns
+	fake.recordInvocation("FindOrganizationInstallation", []interface{}{arg1, arg2})
+	fake.findOrganizationInstallationMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *AppsClient) FindOrganizationInstallationCallCount() int {
+	fake.findOrganizationInstallationMutex.RLock()
+	defer fake.findOrganizationInstallationMutex.RUnlock()
+	return len(fake.findOrganizationInstallationArgsForCall)
+}
+
+func (fake *AppsClient) FindOrganizationInstallationCalls(stub func(context.Context, string) (*github.Installation, *github.Response, error)) {
+	fake.findOrganizationInstallationMutex.Lock()
+	defer fake.findOrganizationInstallationMutex.Unlock()
+	fake.FindOrganizationInstallationStub = stub
+}
+
+func (fake *AppsClient) FindOrganizationInstallationArgsForCall(i int) (context.Context, string) {
+	fake.findOrganizationInstallationMutex.RLock()
+	defer fake.findOrganizationInstallationMutex.RUnlock()
+	argsForCall := fake.findOrganizationInstallationArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *AppsClient) FindOrganizationInstallationReturns(result1 *github.Installation, result2 *github.Response, result3 error) {
+	fake.findOrganizationInstallationMutex.Lock()
+	defer fake.findOrganizationInstallationMutex.Unlock()
+	fake.FindOrganizationInstallationStub = nil
+	fake.findOrganizationInstallationReturns = struct {
+		result1 *github.Installation
+		result2 *github.Response
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *AppsClient) FindOrganizationInstallationReturnsOnCall(i int, result1 *github.Installation, result2 *github.Response, result3 error) {
+	fake.findOrganizationInstallationMutex.Lock()
+	defer fake.findOrganizationInstallationMutex.Unlock()
+	fake.FindOrganizationInstallationStub = nil
+	if fake.findOrganizationInstallationReturnsOnCall == nil {
+		fake.findOrganizationInstallationReturnsOnCall = make(map[int]struct {
+			result1 *github.Installation
+			result2 *github.Response
+			result3 error
+		})
+	}
+	fake.findOrganizationInstallationReturnsOnCall[i] = struct {
+		result1 *github.Installation
+		result2 *github.Response
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *AppsClient) ListInstallations(arg1 context.Context, arg2 *github.ListOptions) ([]*github.Installation, *github.Response, error) {
+	fake.listInstallationsMutex.Lock()
+	ret, specificReturn := fake.listInstallationsReturnsOnCall[len(fake.listInstallationsArgsForCall)]
+	fake.listInstallationsArgsForCall = append(fake.listInstallationsArgsForCall, struct {
+		arg1 context.Context
+		arg2 *github.ListOptions
+	}{arg1, arg2})
+	stub := fake.ListInstallationsStub
+	fakeReturns := fake.listInstallationsReturns
+	fake.recordInvocation("ListInstallations", []interface{}{arg1, arg2})
+	fake.listInstallationsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *AppsClient) ListInstallationsCallCount() int {
+	fake.listInstallationsMutex.RLock()
+	defer fake.listInstallationsMutex.RUnlock()
+	return len(fake.listInstallationsArgsForCall)
+}
+
+func (fake *AppsClient) ListInstallationsCalls(stub func(context.Context, *github.ListOptions) ([]*github.Installation, *github.Response, error)) {
+	fake.listInstallationsMutex.Lock()
+	defer fake.listInstallationsMutex.Unlock()
+	fake.ListInstallationsStub = stub
+}
+
+func (fake *AppsClient) ListInstallationsArgsForCall(i int) (context.Context, *github.ListOptions) {
+	fake.listInstallationsMutex.RLock()
+	defer fake.listInstallationsMutex.RUnlock()
+	argsForCall := fake.listInstallationsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *AppsClient) ListInstallationsReturns(result1 []*github.Installation, result2 *github.Response, result3 error) {
+	fake.listInstallationsMutex.Lock()
+	defer fake.listInstallationsMutex.Unlock()
+	fake.ListInstallationsStub = nil
+	fake.listInstallationsReturns = struct {
+		result1 []*github.Installation
+		result2 *github.Response
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *AppsClient) ListInstallationsReturnsOnCall(i int, result1 []*github.Installation, result2 *github.Response, result3 error) {
+	fake.listInstallationsMutex.Lock()
+	defer fake.listInstallationsMutex.Unlock()
+	fake.ListInstallationsStub = nil
+	if fake.listInstallationsReturnsOnCall == nil {
+		fake.listInstallationsReturnsOnCall = make(map[int]struct {
+			result1 []*github.Installation
+			result2 *github.Response
+			result3 error
+		})
+	}
+	fake.listInstallationsReturnsOnCall[i] = struct {
+		result1 []*github.Installation
+		result2 *github.Response
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *AppsClient) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.findOrganizationInstallationMutex.RLock()
+	defer fake.findOrganizationInstallationMutex.RUnlock()
+	fake.listInstallationsMutex.RLock()
+	defer fake.listInstallationsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *AppsClient) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
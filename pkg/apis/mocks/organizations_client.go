@@ -0,0 +1,124 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package mocks
+
+import (
+	"context"
+	"sync"
+
+	"github.com/google/go-github/v41/github"
+)
+
+type OrganizationsClient struct {
+	GetOrgMembershipStub        func(context.Context, string, string) (*github.Membership, *github.Response, error)
+	getOrgMembershipMutex       sync.RWMutex
+	getOrgMembershipArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}
+	getOrgMembershipReturns struct {
+		result1 *github.Membership
+		result2 *github.Response
+		result3 error
+	}
+	getOrgMembershipReturnsOnCall map[int]struct {
+		result1 *github.Membership
+		result2 *github.Response
+		result3 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *OrganizationsClient) GetOrgMembership(arg1 context.Context, arg2 string, arg3 string) (*github.Membership, *github.Response, error) {
+	fake.getOrgMembershipMutex.Lock()
+	ret, specificReturn := fake.getOrgMembershipReturnsOnCall[len(fake.getOrgMembershipArgsForCall)]
+	fake.getOrgMembershipArgsForCall = append(fake.getOrgMembershipArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.GetOrgMembershipStub
+	fakeReturns := fake.getOrgMembershipReturns
+	fake.recordInvocation("GetOrgMembership", []interface{}{arg1, arg2, arg3})
+	fake.getOrgMembershipMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *OrganizationsClient) GetOrgMembershipCallCount() int {
+	fake.getOrgMembershipMutex.RLock()
+	defer fake.getOrgMembershipMutex.RUnlock()
+	return len(fake.getOrgMembershipArgsForCall)
+}
+
+func (fake *OrganizationsClient) GetOrgMembershipCalls(stub func(context.Context, string, string) (*github.Membership, *github.Response, error)) {
+	fake.getOrgMembershipMutex.Lock()
+	defer fake.getOrgMembershipMutex.Unlock()
+	fake.GetOrgMembershipStub = stub
+}
+
+func (fake *OrganizationsClient) GetOrgMembershipArgsForCall(i int) (context.Context, string, string) {
+	fake.getOrgMembershipMutex.RLock()
+	defer fake.getOrgMembershipMutex.RUnlock()
+	argsForCall := fake.getOrgMembershipArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *OrganizationsClient) GetOrgMembershipReturns(result1 *github.Membership, result2 *github.Response, result3 error) {
+	fake.getOrgMembershipMutex.Lock()
+	defer fake.getOrgMembershipMutex.Unlock()
+	fake.GetOrgMembershipStub = nil
+	fake.getOrgMembershipReturns = struct {
+		result1 *github.Membership
+		result2 *github.Response
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *OrganizationsClient) GetOrgMembershipReturnsOnCall(i int, result1 *github.Membership, result2 *github.Response, result3 error) {
+	fake.getOrgMembershipMutex.Lock()
+	defer fake.getOrgMembershipMutex.Unlock()
+	fake.GetOrgMembershipStub = nil
+	if fake.getOrgMembershipReturnsOnCall == nil {
+		fake.getOrgMembershipReturnsOnCall = make(map[int]struct {
+			result1 *github.Membership
+			result2 *github.Response
+			result3 error
+		})
+	}
+	fake.getOrgMembershipReturnsOnCall[i] = struct {
+		result1 *github.Membership
+		result2 *github.Response
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *OrganizationsClient) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.getOrgMembershipMutex.RLock()
+	defer fake.getOrgMembershipMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *OrganizationsClient) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
@@ -9,6 +9,23 @@ import (
 )
 
 type TeamsClient struct {
+	GetTeamBySlugStub        func(context.Context, string, string) (*github.Team, *github.Response, error)
+	getTeamBySlugMutex       sync.RWMutex
+	getTeamBySlugArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}
+	getTeamBySlugReturns struct {
+		result1 *github.Team
+		result2 *github.Response
+		result3 error
+	}
+	getTeamBySlugReturnsOnCall map[int]struct {
+		result1 *github.Team
+		result2 *github.Response
+		result3 error
+	}
 	GetTeamMembershipBySlugStub        func(context.Context, string, string, string) (*github.Membership, *github.Response, error)
 	getTeamMembershipBySlugMutex       sync.RWMutex
 	getTeamMembershipBySlugArgsForCall []struct {
@@ -49,6 +66,75 @@ type TeamsClient struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *TeamsClient) GetTeamBySlug(arg1 context.Context, arg2 string, arg3 string) (*github.Team, *github.Response, error) {
+	fake.getTeamBySlugMutex.Lock()
+	ret, specificReturn := fake.getTeamBySlugReturnsOnCall[len(fake.getTeamBySlugArgsForCall)]
+	fake.getTeamBySlugArgsForCall = append(fake.getTeamBySlugArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.GetTeamBySlugStub
+	fakeReturns := fake.getTeamBySlugReturns
+	fake.recordInvocation("GetTeamBySlug", []interface{}{arg1, arg2, arg3})
+	fake.getTeamBySlugMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *TeamsClient) GetTeamBySlugCallCount() int {
+	fake.getTeamBySlugMutex.RLock()
+	defer fake.getTeamBySlugMutex.RUnlock()
+	return len(fake.getTeamBySlugArgsForCall)
+}
+
+func (fake *TeamsClient) GetTeamBySlugCalls(stub func(context.Context, string, string) (*github.Team, *github.Response, error)) {
+	fake.getTeamBySlugMutex.Lock()
+	defer fake.getTeamBySlugMutex.Unlock()
+	fake.GetTeamBySlugStub = stub
+}
+
+func (fake *TeamsClient) GetTeamBySlugArgsForCall(i int) (context.Context, string, string) {
+	fake.getTeamBySlugMutex.RLock()
+	defer fake.getTeamBySlugMutex.RUnlock()
+	argsForCall := fake.getTeamBySlugArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *TeamsClient) GetTeamBySlugReturns(result1 *github.Team, result2 *github.Response, result3 error) {
+	fake.getTeamBySlugMutex.Lock()
+	defer fake.getTeamBySlugMutex.Unlock()
+	fake.GetTeamBySlugStub = nil
+	fake.getTeamBySlugReturns = struct {
+		result1 *github.Team
+		result2 *github.Response
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *TeamsClient) GetTeamBySlugReturnsOnCall(i int, result1 *github.Team, result2 *github.Response, result3 error) {
+	fake.getTeamBySlugMutex.Lock()
+	defer fake.getTeamBySlugMutex.Unlock()
+	fake.GetTeamBySlugStub = nil
+	if fake.getTeamBySlugReturnsOnCall == nil {
+		fake.getTeamBySlugReturnsOnCall = make(map[int]struct {
+			result1 *github.Team
+			result2 *github.Response
+			result3 error
+		})
+	}
+	fake.getTeamBySlugReturnsOnCall[i] = struct {
+		result1 *github.Team
+		result2 *github.Response
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *TeamsClient) GetTeamMembershipBySlug(arg1 context.Context, arg2 string, arg3 string, arg4 string) (*github.Membership, *github.Response, error) {
 	fake.getTeamMembershipBySlugMutex.Lock()
 	ret, specificReturn := fake.getTeamMembershipBySlugReturnsOnCall[len(fake.getTeamMembershipBySlugArgsForCall)]
@@ -192,6 +278,8 @@ func (fake *TeamsClient) ListTeamReposBySlugReturnsOnCall(i int, result1 []*gith
 func (fake *TeamsClient) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.getTeamBySlugMutex.RLock()
+	defer fake.getTeamBySlugMutex.RUnlock()
 	fake.getTeamMembershipBySlugMutex.RLock()
 	defer fake.getTeamMembershipBySlugMutex.RUnlock()
 	fake.listTeamReposBySlugMutex.RLock()
@@ -0,0 +1,251 @@
+/**
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package apis
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+const (
+	defaultOIDCIssuer   = "https://token.actions.githubusercontent.com"
+	jwksRefreshInterval = time.Minute
+)
+
+// OIDCVerifier verifies GitHub Actions OIDC tokens against a JSON Web Key Set and maps their claims to teams
+type OIDCVerifier struct {
+	config OIDC
+	client *http.Client
+	now    func() time.Time
+
+	mutex     sync.Mutex
+	keys      map[string]*rsa.PublicKey
+	fetchedAt time.Time
+}
+
+type jsonWebKeySet struct {
+	Keys []jsonWebKey `json:"keys"`
+}
+
+type jsonWebKey struct {
+	KeyID   string `json:"kid"`
+	KeyType string `json:"kty"`
+	N       string `json:"n"`
+	E       string `json:"e"`
+}
+
+// NewOIDCVerifier creates a verifier and loads the JSON Web Key Set from the configured file or URL
+func NewOIDCVerifier(config OIDC, client *http.Client) (*OIDCVerifier, error) {
+	if config.JWKS == "" {
+		return nil, fmt.Errorf("oidc requires a jwks file or url")
+	}
+	if config.Audience == "" {
+		return nil, fmt.Errorf("oidc requires an audience")
+	}
+	if config.Issuer == "" {
+		config.Issuer = defaultOIDCIssuer
+	}
+	if client == nil {
+		client = http.DefaultClient
+	}
+	verifier := &OIDCVerifier{
+		config: config,
+		client: client,
+		now:    time.Now,
+	}
+	if err := verifier.loadKeys(); err != nil {
+		return nil, err
+	}
+	return verifier, nil
+}
+
+// Validate ensures every policy grants a known role on a team and matches at least one claim
+func (o OIDC) Validate() error {
+	for _, policy := range o.Policies {
+		if policy.Team == "" {
+			return fmt.Errorf("oidc policy requires a team")
+		}
+		if policy.Role != "" && !roles[policy.Role] {
+			return fmt.Errorf("unknown role %s in oidc policy for team %s", policy.Role, policy.Team)
+		}
+		if len(policy.Claims) == 0 {
+			return fmt.Errorf("oidc policy for team %s requires at least one claim", policy.Team)
+		}
+	}
+	return nil
+}
+
+// IsOIDCToken reports whether the Authorization header value is formatted as a JSON Web Token rather than a GitHub token
+func IsOIDCToken(token string) bool {
+	token = strings.TrimPrefix(token, "Bearer ")
+	return strings.HasPrefix(token, "eyJ") && strings.Count(token, ".") == 2
+}
+
+// Verify validates the signature, issuer, audience and lifetime of the token and returns its claims
+func (v *OIDCVerifier) Verify(token string) (jwt.MapClaims, error) {
+	token = strings.TrimPrefix(token, "Bearer ")
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(token, claims, func(t *jwt.Token) (interface{}, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodRSA); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %s", t.Header["alg"])
+		}
+		kid, _ := t.Header["kid"].(string)
+		return v.key(kid)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("invalid oidc token: %w", err)
+	}
+
+	now := v.now().Unix()
+	if !claims.VerifyExpiresAt(now, true) {
+		return nil, fmt.Errorf("invalid oidc token: token is expired")
+	}
+	if !claims.VerifyIssuer(v.config.Issuer, true) {
+		return nil, fmt.Errorf("invalid oidc token: unexpected issuer")
+	}
+	if !claims.VerifyAudience(v.config.Audience, true) {
+		return nil, fmt.Errorf("invalid oidc token: unexpected audience")
+	}
+	return claims, nil
+}
+
+// Roles returns the roles every configured policy matching the claims grants on the team of the organization. A policy
+// without an organization only matches when the server manages a single organization.
+func (v *OIDCVerifier) Roles(claims jwt.MapClaims, org, team string, singleOrg bool) []string {
+	var roles []string
+	for _, policy := range v.config.Policies {
+		if policy.matches(claims, org, team, singleOrg) {
+			role := policy.Role
+			if role == "" {
+				role = RoleMaintainer
+			}
+			roles = append(roles, role)
+		}
+	}
+	return roles
+}
+
+func (p OIDCPolicy) matches(claims jwt.MapClaims, org, team string, singleOrg bool) bool {
+	if p.Org == "" && !singleOrg || p.Org != "" && !strings.EqualFold(p.Org, org) {
+		return false
+	}
+	if matched, _ := path.Match(p.Team, team); !matched {
+		return false
+	}
+	if len(p.Claims) == 0 {
+		return false
+	}
+	for name, pattern := range p.Claims {
+		value, ok := claims[name].(string)
+		if !ok {
+			return false
+		}
+		if matched, _ := path.Match(pattern, value); !matched {
+			return false
+		}
+	}
+	return true
+}
+
+func (v *OIDCVerifier) key(kid string) (*rsa.PublicKey, error) {
+	v.mutex.Lock()
+	key, ok := v.keys[kid]
+	stale := v.now().Sub(v.fetchedAt) > jwksRefreshInterval
+	v.mutex.Unlock()
+	if ok {
+		return key, nil
+	}
+
+	// Keys are rotated by the issuer, so refresh the key set when an unknown key is presented
+	if stale && isURL(v.config.JWKS) {
+		if err := v.loadKeys(); err != nil {
+			return nil, err
+		}
+		v.mutex.Lock()
+		key, ok = v.keys[kid]
+		v.mutex.Unlock()
+		if ok {
+			return key, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown signing key: %s", kid)
+}
+
+func (v *OIDCVerifier) loadKeys() error {
+	var bytes []byte
+	var err error
+	if isURL(v.config.JWKS) {
+		bytes, err = v.fetchKeys()
+	} else {
+		bytes, err = ioutil.ReadFile(v.config.JWKS)
+	}
+	if err != nil {
+		return fmt.Errorf("unable to load jwks: %w", err)
+	}
+
+	keySet := &jsonWebKeySet{}
+	if err := json.Unmarshal(bytes, keySet); err != nil {
+		return fmt.Errorf("unable to parse jwks: %w", err)
+	}
+	keys := map[string]*rsa.PublicKey{}
+	for _, jwk := range keySet.Keys {
+		if jwk.KeyType != "RSA" {
+			continue
+		}
+		key, err := jwk.publicKey()
+		if err != nil {
+			return fmt.Errorf("unable to parse jwks key %s: %w", jwk.KeyID, err)
+		}
+		keys[jwk.KeyID] = key
+	}
+
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	v.keys = keys
+	v.fetchedAt = v.now()
+	return nil
+}
+
+func (v *OIDCVerifier) fetchKeys() ([]byte, error) {
+	resp, err := v.client.Get(v.config.JWKS)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+	return ioutil.ReadAll(resp.Body)
+}
+
+func (k jsonWebKey) publicKey() (*rsa.PublicKey, error) {
+	n, err := base64.RawURLEncoding.DecodeString(k.N)
+	if err != nil {
+		return nil, err
+	}
+	e, err := base64.RawURLEncoding.DecodeString(k.E)
+	if err != nil {
+		return nil, err
+	}
+	return &rsa.PublicKey{
+		N: new(big.Int).SetBytes(n),
+		E: int(new(big.Int).SetBytes(e).Int64()),
+	}, nil
+}
+
+func isURL(location string) bool {
+	return strings.HasPrefix(location, "https://") || strings.HasPrefix(location, "http://")
+}
@@ -0,0 +1,265 @@
+/**
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package apis
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/go-github/v41/github"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+)
+
+func writeJWKS(t *testing.T, kid string, key *rsa.PrivateKey) string {
+	bytes, err := json.Marshal(&jsonWebKeySet{
+		Keys: []jsonWebKey{
+			{
+				KeyID:   kid,
+				KeyType: "RSA",
+				N:       base64.RawURLEncoding.EncodeToString(key.PublicKey.N.Bytes()),
+				E:       base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.PublicKey.E)).Bytes()),
+			},
+		},
+	})
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	err = ioutil.WriteFile(path, bytes, 0o600)
+	require.NoError(t, err)
+	return path
+}
+
+func signOIDCToken(t *testing.T, kid string, key *rsa.PrivateKey, claims jwt.MapClaims) string {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = kid
+	signed, err := token.SignedString(key)
+	require.NoError(t, err)
+	return signed
+}
+
+func oidcClaims(repository string) jwt.MapClaims {
+	return jwt.MapClaims{
+		"aud":              "actions-runner-manager",
+		"exp":              time.Now().Add(time.Minute).Unix(),
+		"iss":              defaultOIDCIssuer,
+		"sub":              "repo:fake-org/" + repository + ":ref:refs/heads/main",
+		"repository":       "fake-org/" + repository,
+		"repository_owner": "fake-org",
+		"job_workflow_ref": "fake-org/platform/.github/workflows/runners.yml@refs/heads/main",
+	}
+}
+
+func TestOIDCVerifier(t *testing.T) {
+	t.Parallel()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	verifier, err := NewOIDCVerifier(OIDC{
+		Audience: "actions-runner-manager",
+		JWKS:     writeJWKS(t, "fake-kid", key),
+		Policies: []OIDCPolicy{
+			{
+				Team: "fake-team",
+				Claims: map[string]string{
+					"repository_owner": "fake-org",
+					"job_workflow_ref": "fake-org/platform/.github/workflows/*@refs/heads/main",
+				},
+			},
+			{
+				Team: "other-*",
+				Role: RoleMember,
+				Claims: map[string]string{
+					"repository": "fake-org/other-repo",
+				},
+			},
+		},
+	}, nil)
+	require.NoError(t, err)
+
+	claims, err := verifier.Verify("Bearer " + signOIDCToken(t, "fake-kid", key, oidcClaims("fake-repo")))
+	require.NoError(t, err)
+	require.Equal(t, "fake-org/fake-repo", claims["repository"])
+	require.Equal(t, []string{RoleMaintainer}, verifier.Roles(claims, "fake-org", "fake-team", true))
+	require.Empty(t, verifier.Roles(claims, "fake-org", "fake-team", false))
+	require.Empty(t, verifier.Roles(claims, "fake-org", "other-team", true))
+
+	claims, err = verifier.Verify(signOIDCToken(t, "fake-kid", key, oidcClaims("other-repo")))
+	require.NoError(t, err)
+	require.Equal(t, []string{RoleMember}, verifier.Roles(claims, "fake-org", "other-team", true))
+
+	expired := oidcClaims("fake-repo")
+	expired["exp"] = time.Now().Add(-time.Minute).Unix()
+	wrongAudience := oidcClaims("fake-repo")
+	wrongAudience["aud"] = "someone-else"
+	wrongIssuer := oidcClaims("fake-repo")
+	wrongIssuer["iss"] = "https://example.com"
+	missingExpiry := oidcClaims("fake-repo")
+	delete(missingExpiry, "exp")
+
+	invalid := []string{
+		signOIDCToken(t, "fake-kid", key, expired),
+		signOIDCToken(t, "fake-kid", key, wrongAudience),
+		signOIDCToken(t, "fake-kid", key, wrongIssuer),
+		signOIDCToken(t, "fake-kid", key, missingExpiry),
+		signOIDCToken(t, "fake-kid", otherKey, oidcClaims("fake-repo")),
+		signOIDCToken(t, "unknown-kid", key, oidcClaims("fake-repo")),
+	}
+	for _, token := range invalid {
+		_, err = verifier.Verify(token)
+		require.Error(t, err)
+	}
+}
+
+func TestOIDCVerifier_URL(t *testing.T) {
+	t.Parallel()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	jwks, err := ioutil.ReadFile(writeJWKS(t, "fake-kid", key))
+	require.NoError(t, err)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(jwks)
+	}))
+	defer server.Close()
+
+	verifier, err := NewOIDCVerifier(OIDC{
+		Audience: "actions-runner-manager",
+		JWKS:     server.URL,
+	}, server.Client())
+	require.NoError(t, err)
+
+	claims, err := verifier.Verify(signOIDCToken(t, "fake-kid", key, oidcClaims("fake-repo")))
+	require.NoError(t, err)
+	require.Empty(t, verifier.Roles(claims, "fake-org", "fake-team", true))
+}
+
+func TestRequireMaintainer_OIDC(t *testing.T) {
+	t.Parallel()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	verifier, err := NewOIDCVerifier(OIDC{
+		Audience: "actions-runner-manager",
+		JWKS:     writeJWKS(t, "fake-kid", key),
+		Policies: []OIDCPolicy{
+			{
+				Team:   "fake-team",
+				Claims: map[string]string{"repository": "fake-org/fake-repo"},
+			},
+		},
+	}, nil)
+	require.NoError(t, err)
+
+	logger, _ := test.NewNullLogger()
+	manager := &Manager{
+		Config: &Config{},
+		CreateMaintainershipClient: func(string, string) (*MaintainershipClient, *github.User, error) {
+			t.Fatal("OIDC tokens must not be used to create a GitHub client")
+			return nil, nil, nil
+		},
+		Logger: logger,
+		OIDC:   verifier,
+	}
+	router := gin.New()
+	router.GET("/", append(manager.AuthorizationHandlers(), manager.RequirePermission(OperationGroupList), func(c *gin.Context) {
+		c.String(http.StatusOK, c.GetString(userKey))
+	})...)
+
+	tests := []struct {
+		team     string
+		repo     string
+		expected int
+	}{
+		{team: "fake-team", repo: "fake-repo", expected: http.StatusOK},
+		{team: "fake-team", repo: "other-repo", expected: http.StatusUnauthorized},
+		{team: "other-team", repo: "fake-repo", expected: http.StatusUnauthorized},
+	}
+	for _, tc := range tests {
+		writer := httptest.NewRecorder()
+		request, err := http.NewRequest(http.MethodGet, "/?team="+tc.team, nil)
+		require.NoError(t, err)
+		request.Header.Set("Authorization", "Bearer "+signOIDCToken(t, "fake-kid", key, oidcClaims(tc.repo)))
+		router.ServeHTTP(writer, request)
+		require.Equal(t, tc.expected, writer.Code)
+		if tc.expected == http.StatusOK {
+			require.Equal(t, "repo:fake-org/fake-repo:ref:refs/heads/main", writer.Body.String())
+		}
+	}
+}
+
+func TestRequireMaintainer_OIDCOrganizations(t *testing.T) {
+	t.Parallel()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	verifier, err := NewOIDCVerifier(OIDC{
+		Audience: "actions-runner-manager",
+		JWKS:     writeJWKS(t, "fake-kid", key),
+		Policies: []OIDCPolicy{
+			{
+				Org:    "fake-org",
+				Team:   "fake-team",
+				Claims: map[string]string{"repository": "fake-org/fake-repo"},
+			},
+			{
+				Team:   "fake-team",
+				Claims: map[string]string{"repository": "fake-org/other-repo"},
+			},
+		},
+	}, nil)
+	require.NoError(t, err)
+
+	logger, _ := test.NewNullLogger()
+	manager := &Manager{
+		Config: &Config{Org: "fake-org", Orgs: []Organization{{Name: "other-org"}}},
+		CreateMaintainershipClient: func(string, string) (*MaintainershipClient, *github.User, error) {
+			t.Fatal("OIDC tokens must not be used to create a GitHub client")
+			return nil, nil, nil
+		},
+		Logger: logger,
+		OIDC:   verifier,
+	}
+	manager.AddOrganization("other-org", 4, &OrganizationClients{})
+	router := gin.New()
+	for _, org := range []string{"fake-org", "other-org"} {
+		scoped := manager.organization(org)
+		router.GET("/"+org, append(scoped.AuthorizationHandlers(), scoped.RequirePermission(OperationGroupList), func(c *gin.Context) {
+			c.Status(http.StatusOK)
+		})...)
+	}
+
+	tests := []struct {
+		org      string
+		repo     string
+		expected int
+	}{
+		{org: "fake-org", repo: "fake-repo", expected: http.StatusOK},
+		{org: "other-org", repo: "fake-repo", expected: http.StatusUnauthorized},
+		{org: "fake-org", repo: "other-repo", expected: http.StatusUnauthorized},
+		{org: "other-org", repo: "other-repo", expected: http.StatusUnauthorized},
+	}
+	for _, tc := range tests {
+		writer := httptest.NewRecorder()
+		request, err := http.NewRequest(http.MethodGet, "/"+tc.org+"?team=fake-team", nil)
+		require.NoError(t, err)
+		request.Header.Set("Authorization", "Bearer "+signOIDCToken(t, "fake-kid", key, oidcClaims(tc.repo)))
+		router.ServeHTTP(writer, request)
+		require.Equal(t, tc.expected, writer.Code, tc.org+"/"+tc.repo)
+	}
+}
@@ -0,0 +1,176 @@
+/**
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package apis
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/google/go-github/v41/github"
+)
+
+// Organization configures an additional organization managed by the server. When InstallationID is unset, the
+// installation of the GitHub App in the organization is discovered on startup.
+type Organization struct {
+	Name           string `yaml:"name"`
+	InstallationID int64  `yaml:"installationID"`
+}
+
+// OrganizationClients holds the clients authenticated as the installation of the GitHub App in an organization
+type OrganizationClients struct {
+	ActionsClient      actionsClient
+	RepositoriesClient repositoriesClient
+	RestClient         restClient
+	TeamsClient        teamsClient
+	TokenSource        tokenSource
+
+	Cache *MaintainershipCache
+}
+
+// ValidateOrgs verifies at least one organization is configured and no organization is configured twice
+func (c *Config) ValidateOrgs() error {
+	if c.Org == "" && len(c.Orgs) == 0 && !c.DiscoverInstallations {
+		return fmt.Errorf("at least one of org, orgs or discoverInstallations must be set")
+	}
+	seen := map[string]bool{strings.ToLower(c.Org): c.Org != ""}
+	for _, org := range c.Orgs {
+		if org.Name == "" {
+			return fmt.Errorf("every organization in orgs must have a name")
+		}
+		name := strings.ToLower(org.Name)
+		if seen[name] {
+			return fmt.Errorf("organization %s is configured more than once", org.Name)
+		}
+		seen[name] = true
+	}
+	return nil
+}
+
+// managesMultipleOrganizations reports whether more than one organization is configured, including the organizations
+// discovered by ResolveInstallations
+func (c *Config) managesMultipleOrganizations() bool {
+	count := len(c.Orgs)
+	if c.Org != "" {
+		count++
+	}
+	return count > 1
+}
+
+// ResolveInstallations looks up the installation of the GitHub App in every configured organization without an
+// installation ID, and when DiscoverInstallations is set, adds every other organization the GitHub App is installed in
+func (c *Config) ResolveInstallations(ctx context.Context, apps appsClient) error {
+	if c.Org != "" && c.InstallationID == 0 {
+		installation, _, err := apps.FindOrganizationInstallation(ctx, c.Org)
+		if err != nil {
+			return fmt.Errorf("unable to find installation in organization %s: %w", c.Org, err)
+		}
+		c.InstallationID = installation.GetID()
+	}
+	for i := range c.Orgs {
+		if c.Orgs[i].InstallationID != 0 {
+			continue
+		}
+		installation, _, err := apps.FindOrganizationInstallation(ctx, c.Orgs[i].Name)
+		if err != nil {
+			return fmt.Errorf("unable to find installation in organization %s: %w", c.Orgs[i].Name, err)
+		}
+		c.Orgs[i].InstallationID = installation.GetID()
+	}
+	if !c.DiscoverInstallations {
+		return nil
+	}
+
+	configured := map[string]bool{strings.ToLower(c.Org): c.Org != ""}
+	for _, org := range c.Orgs {
+		configured[strings.ToLower(org.Name)] = true
+	}
+	opts := &github.ListOptions{PerPage: 100}
+	for {
+		installations, resp, err := apps.ListInstallations(ctx, opts)
+		if err != nil {
+			return fmt.Errorf("unable to list installations: %w", err)
+		}
+		for _, installation := range installations {
+			account := installation.GetAccount()
+			if account.GetType() != "Organization" || configured[strings.ToLower(account.GetLogin())] {
+				continue
+			}
+			configured[strings.ToLower(account.GetLogin())] = true
+			c.Orgs = append(c.Orgs, Organization{Name: account.GetLogin(), InstallationID: installation.GetID()})
+		}
+		if resp.NextPage == 0 {
+			return nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// AddOrganization registers an organization whose API routes are served under /api/v1/orgs/<name>. The organization
+// is served by a copy of the manager that talks to the organization through its own clients and tracks the offline
+// runners of the organization on its own, as runner IDs are only unique within an organization.
+func (m *Manager) AddOrganization(name string, installationID int64, clients *OrganizationClients) {
+	if m.Organizations == nil {
+		m.Organizations = map[string]*Manager{}
+	}
+	if strings.EqualFold(name, m.Config.Org) {
+		m.Organizations[strings.ToLower(name)] = m
+		return
+	}
+
+	config := *m.Config
+	config.Org = name
+	config.InstallationID = installationID
+	scoped := *m
+	scoped.ActionsClient = clients.ActionsClient
+	scoped.RepositoriesClient = clients.RepositoriesClient
+	scoped.RestClient = clients.RestClient
+	scoped.TeamsClient = clients.TeamsClient
+	scoped.TokenSource = clients.TokenSource
+	scoped.Cache = clients.Cache
+	scoped.Config = &config
+	scoped.defaultOrg = m.defaultOrganization()
+	if m.Offline != nil {
+		scoped.Offline = NewOfflineRunners()
+	}
+	m.Organizations[strings.ToLower(name)] = &scoped
+}
+
+// defaultOrganization returns the organization configured in org, which the manager of every other organization was
+// added to
+func (m *Manager) defaultOrganization() string {
+	if m.defaultOrg != "" {
+		return m.defaultOrg
+	}
+	return m.Config.Org
+}
+
+// organization returns the manager of the organization with the login, or nil if the organization is not managed
+func (m *Manager) organization(login string) *Manager {
+	if m.Config.Org != "" && strings.EqualFold(login, m.Config.Org) {
+		return m
+	}
+	return m.Organizations[strings.ToLower(login)]
+}
+
+// managedOrganizations returns the manager of every managed organization, starting with the default organization
+func (m *Manager) managedOrganizations() []*Manager {
+	var managers []*Manager
+	if m.Config.Org != "" {
+		managers = append(managers, m)
+	}
+	var names []string
+	for name, manager := range m.Organizations {
+		if manager != m {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		managers = append(managers, m.Organizations[name])
+	}
+	return managers
+}
@@ -0,0 +1,168 @@
+/**
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package apis
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/didip/tollbooth/v6"
+	"github.com/gin-gonic/gin"
+	"github.com/google/go-github/v41/github"
+	"github.com/lindluni/actions-runner-manager/pkg/apis/mocks"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig_ValidateOrgs(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, (&Config{Org: "fake-org"}).ValidateOrgs())
+	require.NoError(t, (&Config{Orgs: []Organization{{Name: "fake-org"}, {Name: "other-org"}}}).ValidateOrgs())
+	require.NoError(t, (&Config{DiscoverInstallations: true}).ValidateOrgs())
+	require.EqualError(t, (&Config{}).ValidateOrgs(), "at least one of org, orgs or discoverInstallations must be set")
+	require.EqualError(t, (&Config{Orgs: []Organization{{}}}).ValidateOrgs(), "every organization in orgs must have a name")
+	require.EqualError(t, (&Config{Org: "fake-org", Orgs: []Organization{{Name: "Fake-Org"}}}).ValidateOrgs(), "organization Fake-Org is configured more than once")
+}
+
+func TestConfig_ResolveInstallations(t *testing.T) {
+	t.Parallel()
+
+	appsClient := &mocks.AppsClient{}
+	appsClient.FindOrganizationInstallationStub = func(_ context.Context, org string) (*github.Installation, *github.Response, error) {
+		switch org {
+		case "fake-org":
+			return &github.Installation{ID: github.Int64(1)}, &github.Response{}, nil
+		case "other-org":
+			return &github.Installation{ID: github.Int64(2)}, &github.Response{}, nil
+		}
+		return nil, &github.Response{}, fmt.Errorf("fake-error")
+	}
+	appsClient.ListInstallationsReturns([]*github.Installation{
+		{ID: github.Int64(1), Account: &github.User{Login: github.String("Fake-Org"), Type: github.String("Organization")}},
+		{ID: github.Int64(3), Account: &github.User{Login: github.String("fake-user"), Type: github.String("User")}},
+		{ID: github.Int64(4), Account: &github.User{Login: github.String("discovered-org"), Type: github.String("Organization")}},
+	}, &github.Response{}, nil)
+
+	config := &Config{
+		Org:  "fake-org",
+		Orgs: []Organization{{Name: "other-org"}, {Name: "pinned-org", InstallationID: 5}},
+	}
+	require.NoError(t, config.ResolveInstallations(context.Background(), appsClient))
+	require.Equal(t, int64(1), config.InstallationID)
+	require.Equal(t, []Organization{{Name: "other-org", InstallationID: 2}, {Name: "pinned-org", InstallationID: 5}}, config.Orgs)
+	require.Equal(t, 2, appsClient.FindOrganizationInstallationCallCount())
+	require.Equal(t, 0, appsClient.ListInstallationsCallCount())
+
+	config.DiscoverInstallations = true
+	require.NoError(t, config.ResolveInstallations(context.Background(), appsClient))
+	require.Equal(t, []Organization{
+		{Name: "other-org", InstallationID: 2},
+		{Name: "pinned-org", InstallationID: 5},
+		{Name: "discovered-org", InstallationID: 4},
+	}, config.Orgs)
+
+	config = &Config{Orgs: []Organization{{Name: "missing-org"}}}
+	require.EqualError(t, config.ResolveInstallations(context.Background(), appsClient), "unable to find installation in organization missing-org: fake-error")
+}
+
+func TestAddOrganization(t *testing.T) {
+	t.Parallel()
+
+	newActionsClient := func(groupID int64) *mocks.ActionsClient {
+		actionsClient := &mocks.ActionsClient{}
+		actionsClient.ListOrganizationRunnerGroupsReturns(&github.RunnerGroups{
+			RunnerGroups: []*github.RunnerGroup{{ID: github.Int64(groupID), Name: github.String("fake-team")}},
+		}, &github.Response{}, nil)
+		actionsClient.ListRunnerGroupRunnersReturns(&github.Runners{}, &github.Response{}, nil)
+		return actionsClient
+	}
+	defaultClient := newActionsClient(2)
+	otherClient := newActionsClient(3)
+	teamsClient := &mocks.TeamsClient{}
+	teamsClient.GetTeamMembershipBySlugReturns(&github.Membership{Role: github.String("maintainer")}, nil, nil)
+	logger, _ := test.NewNullLogger()
+	manager := &Manager{
+		ActionsClient: defaultClient,
+		Config:        &Config{Org: "fake-org"},
+		CreateMaintainershipClient: func(string, string) (*MaintainershipClient, *github.User, error) {
+			return &MaintainershipClient{
+				TeamsClient: teamsClient,
+			}, &github.User{Login: github.String("fake-user")}, nil
+		},
+		Limit:   tollbooth.NewLimiter(1, nil),
+		Logger:  logger,
+		Offline: NewOfflineRunners(),
+		Router:  gin.New(),
+	}
+	manager.AddOrganization("other-org", 4, &OrganizationClients{ActionsClient: otherClient})
+	manager.AddOrganization("Fake-Org", 1, &OrganizationClients{ActionsClient: otherClient})
+	manager.SetRoutes()
+
+	require.Same(t, manager, manager.organization("FAKE-ORG"))
+	require.Equal(t, "other-org", manager.organization("Other-Org").Config.Org)
+	require.Equal(t, int64(4), manager.organization("other-org").Config.InstallationID)
+	require.Nil(t, manager.organization("unknown-org"))
+	require.Len(t, manager.managedOrganizations(), 2)
+	require.Equal(t, "fake-org", manager.Config.Org)
+	require.NotNil(t, manager.organization("other-org").Offline)
+	require.NotSame(t, manager.Offline, manager.organization("other-org").Offline)
+
+	tests := []struct {
+		url       string
+		client    *mocks.ActionsClient
+		org       string
+		groupID   int64
+		teamCalls int
+	}{
+		{url: "/api/v1/runner-list?team=fake-team", client: defaultClient, org: "fake-org", groupID: 2, teamCalls: 1},
+		{url: "/api/v1/orgs/fake-org/runner-list?team=fake-team", client: defaultClient, org: "fake-org", groupID: 2, teamCalls: 2},
+		{url: "/api/v1/orgs/other-org/runner-list?team=fake-team", client: otherClient, org: "other-org", groupID: 3, teamCalls: 3},
+	}
+	for i, tc := range tests {
+		writer := httptest.NewRecorder()
+		request, err := http.NewRequest(http.MethodGet, tc.url, nil)
+		require.NoError(t, err, tc.url)
+		request.Header.Set("Authorization", "test-token")
+		manager.Router.ServeHTTP(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code, tc.url)
+
+		calls := tc.client.ListRunnerGroupRunnersCallCount()
+		_, org, groupID, _ := tc.client.ListRunnerGroupRunnersArgsForCall(calls - 1)
+		require.Equal(t, tc.org, org, tc.url)
+		require.Equal(t, tc.groupID, groupID, tc.url)
+		_, org, _, _ = teamsClient.GetTeamMembershipBySlugArgsForCall(i)
+		require.Equal(t, tc.org, org, tc.url)
+		require.Equal(t, tc.teamCalls, teamsClient.GetTeamMembershipBySlugCallCount(), tc.url)
+	}
+
+	writer := httptest.NewRecorder()
+	request, err := http.NewRequest(http.MethodGet, "/api/v1/orgs/unknown-org/runner-list?team=fake-team", nil)
+	require.NoError(t, err)
+	request.Header.Set("Authorization", "test-token")
+	manager.Router.ServeHTTP(writer, request)
+	require.Equal(t, http.StatusNotFound, writer.Code)
+}
+
+func TestAuditFilter_Org(t *testing.T) {
+	t.Parallel()
+
+	filter := &AuditFilter{Org: "fake-org", DefaultOrg: "fake-org", Team: "fake-team"}
+	require.True(t, filter.Matches(&AuditEvent{Org: "Fake-Org", Team: "fake-team"}))
+	require.True(t, filter.Matches(&AuditEvent{Team: "fake-team"}))
+	require.False(t, filter.Matches(&AuditEvent{Org: "other-org", Team: "fake-team"}))
+
+	filter = &AuditFilter{Org: "other-org", DefaultOrg: "fake-org", Team: "fake-team"}
+	require.True(t, filter.Matches(&AuditEvent{Org: "other-org", Team: "fake-team"}))
+	require.False(t, filter.Matches(&AuditEvent{Team: "fake-team"}), "events without an organization belong to the default organization")
+
+	manager := &Manager{Config: &Config{Org: "fake-org"}}
+	manager.AddOrganization("other-org", 4, &OrganizationClients{})
+	require.Equal(t, "fake-org", manager.defaultOrganization())
+	require.Equal(t, "fake-org", manager.organization("other-org").defaultOrganization())
+}
@@ -0,0 +1,134 @@
+/**
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package apis
+
+import (
+	"fmt"
+)
+
+const (
+	OperationAuditList      = "audit"
+	OperationGroupCreate    = "group-create"
+	OperationGroupDelete    = "group-delete"
+	OperationGroupList      = "group-list"
+	OperationGroupsList     = "groups-list"
+	OperationGroupUpdate    = "group-update"
+	OperationJanitorReport  = "janitor-report"
+	OperationLabelsAdd      = "labels-add"
+	OperationLabelsList     = "labels-list"
+	OperationLabelsRemove   = "labels-remove"
+	OperationLabelsSet      = "labels-set"
+	OperationReposAdd       = "repos-add"
+	OperationReposRemove    = "repos-remove"
+	OperationReposSet       = "repos-set"
+	OperationRunnerDelete   = "runner-delete"
+	OperationRunnerList     = "runner-list"
+	OperationRunnerRegister = "runner-register"
+	OperationTokenRegister  = "token-register"
+	OperationTokenRemove    = "token-remove"
+	OperationWorkflowsList  = "workflows-list"
+	OperationWorkflowsSet   = "workflows-set"
+)
+
+const (
+	RoleMember     = "member"
+	RoleMaintainer = "maintainer"
+	RoleOwner      = "owner"
+
+	// AllOperations grants a role every operation
+	AllOperations = "*"
+)
+
+var operations = map[string]bool{
+	OperationAuditList:      true,
+	OperationGroupCreate:    true,
+	OperationGroupDelete:    true,
+	OperationGroupList:      true,
+	OperationGroupsList:     true,
+	OperationGroupUpdate:    true,
+	OperationJanitorReport:  true,
+	OperationLabelsAdd:      true,
+	OperationLabelsList:     true,
+	OperationLabelsRemove:   true,
+	OperationLabelsSet:      true,
+	OperationReposAdd:       true,
+	OperationReposRemove:    true,
+	OperationReposSet:       true,
+	OperationRunnerDelete:   true,
+	OperationRunnerList:     true,
+	OperationRunnerRegister: true,
+	OperationTokenRegister:  true,
+	OperationTokenRemove:    true,
+	OperationWorkflowsList:  true,
+	OperationWorkflowsSet:   true,
+}
+
+var roles = map[string]bool{
+	RoleMember:     true,
+	RoleMaintainer: true,
+	RoleOwner:      true,
+}
+
+// Policy grants API operations to the roles a caller holds. Callers hold the member or maintainer role of the team
+// they act on and the owner role on every team if they are an owner of the organization. When no roles are configured
+// team maintainers are granted every operation. When InheritMaintainership is set, maintainers of any ancestor of the
+// team hold the maintainer role of the team.
+type Policy struct {
+	BrokeredRegistration  bool                `yaml:"brokeredRegistration"`
+	InheritMaintainership bool                `yaml:"inheritMaintainership"`
+	Roles                 map[string][]string `yaml:"roles"`
+}
+
+// Validate ensures the policy only references known roles and operations, and does not grant the organization-wide token
+// operations when runners may only be registered through brokered registration
+func (p Policy) Validate() error {
+	for role, ops := range p.Roles {
+		if !roles[role] {
+			return fmt.Errorf("unknown policy role %s", role)
+		}
+		for _, op := range ops {
+			if op != AllOperations && !operations[op] {
+				return fmt.Errorf("unknown policy operation %s for role %s", op, role)
+			}
+			if p.BrokeredRegistration && p.organizationTokenOperation(op) {
+				return fmt.Errorf("policy operation %s for role %s is disabled by brokeredRegistration", op, role)
+			}
+		}
+	}
+	return nil
+}
+
+// organizationTokenOperation reports whether the operation issues a token valid for every runner group of the
+// organization
+func (p Policy) organizationTokenOperation(operation string) bool {
+	return operation == OperationTokenRegister || operation == OperationTokenRemove
+}
+
+// Allows reports whether any of the roles is granted the operation
+func (p Policy) Allows(roles []string, operation string) bool {
+	grants := p.grants()
+	for _, role := range roles {
+		for _, op := range grants[role] {
+			if op == AllOperations || op == operation {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Uses reports whether the policy grants the role any operation
+func (p Policy) Uses(role string) bool {
+	return len(p.grants()[role]) > 0
+}
+
+func (p Policy) grants() map[string][]string {
+	if len(p.Roles) == 0 {
+		return map[string][]string{
+			RoleMaintainer: {AllOperations},
+		}
+	}
+	return p.Roles
+}
@@ -0,0 +1,169 @@
+/**
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package apis
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-github/v41/github"
+	"github.com/lindluni/actions-runner-manager/pkg/apis/mocks"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPolicy_Allows(t *testing.T) {
+	t.Parallel()
+
+	defaultPolicy := Policy{}
+	require.True(t, defaultPolicy.Allows([]string{RoleMaintainer}, OperationReposSet))
+	require.False(t, defaultPolicy.Allows([]string{RoleMember}, OperationGroupList))
+	require.False(t, defaultPolicy.Allows([]string{RoleOwner}, OperationGroupList))
+	require.False(t, defaultPolicy.Allows(nil, OperationGroupList))
+	require.False(t, defaultPolicy.Uses(RoleOwner))
+
+	policy := Policy{
+		Roles: map[string][]string{
+			RoleMember:     {OperationGroupList, OperationTokenRegister},
+			RoleMaintainer: {AllOperations},
+			RoleOwner:      {AllOperations},
+		},
+	}
+	require.NoError(t, policy.Validate())
+	require.True(t, policy.Allows([]string{RoleMember}, OperationTokenRegister))
+	require.False(t, policy.Allows([]string{RoleMember}, OperationReposSet))
+	require.True(t, policy.Allows([]string{RoleMember, RoleOwner}, OperationGroupDelete))
+	require.True(t, policy.Uses(RoleOwner))
+}
+
+func TestPolicy_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		policy    Policy
+		errString string
+	}{
+		{
+			policy:    Policy{Roles: map[string][]string{"admin": {AllOperations}}},
+			errString: "unknown policy role admin",
+		},
+		{
+			policy:    Policy{Roles: map[string][]string{RoleMember: {"group-destroy"}}},
+			errString: "unknown policy operation group-destroy for role member",
+		},
+		{
+			policy:    Policy{BrokeredRegistration: true, Roles: map[string][]string{RoleMember: {OperationRunnerRegister, OperationTokenRegister}}},
+			errString: "policy operation token-register for role member is disabled by brokeredRegistration",
+		},
+	}
+	for _, tc := range tests {
+		require.EqualError(t, tc.policy.Validate(), tc.errString)
+	}
+}
+
+func TestResolveRoles_Owner(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		orgRole  string
+		expected []string
+		err      string
+	}{
+		{orgRole: "admin", expected: []string{RoleOwner}},
+		{orgRole: "member", err: "unable to locate team fake-team"},
+	}
+
+	logger, _ := test.NewNullLogger()
+	for _, tc := range tests {
+		teamsClient := &mocks.TeamsClient{}
+		teamsClient.GetTeamMembershipBySlugReturns(nil, &github.Response{Response: &http.Response{StatusCode: http.StatusNotFound}}, &github.ErrorResponse{})
+		organizationsClient := &mocks.OrganizationsClient{}
+		organizationsClient.GetOrgMembershipReturns(&github.Membership{Role: github.String(tc.orgRole)}, nil, nil)
+		manager := &Manager{
+			Config: &Config{
+				Org: "fake-org",
+				Policy: Policy{
+					Roles: map[string][]string{
+						RoleMaintainer: {AllOperations},
+						RoleOwner:      {AllOperations},
+					},
+				},
+			},
+			CreateMaintainershipClient: func(string, string) (*MaintainershipClient, *github.User, error) {
+				return &MaintainershipClient{
+					OrganizationsClient: organizationsClient,
+					TeamsClient:         teamsClient,
+				}, &github.User{Login: github.String("fake-user")}, nil
+			},
+			Logger: logger,
+		}
+
+		resolved, err := manager.resolveRoles("fake-token", "fake-team", "fake-uuid")
+		if tc.err != "" {
+			require.EqualError(t, err, tc.err)
+		} else {
+			require.NoError(t, err)
+			require.Equal(t, "fake-user", resolved.login)
+			require.Equal(t, tc.expected, resolved.roles)
+		}
+		_, _, org := organizationsClient.GetOrgMembershipArgsForCall(0)
+		require.Equal(t, "fake-org", org)
+	}
+}
+
+func TestResolveRoles_InheritMaintainership(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		inherit          bool
+		maintainedTeam   string
+		expectedRoles    []string
+		expectedAncestry []string
+	}{
+		{inherit: true, maintainedTeam: "platform", expectedRoles: []string{RoleMember, RoleMaintainer}, expectedAncestry: []string{"child", "middle", "platform"}},
+		{inherit: true, maintainedTeam: "middle", expectedRoles: []string{RoleMember, RoleMaintainer}, expectedAncestry: []string{"child", "middle"}},
+		{inherit: true, maintainedTeam: "unrelated", expectedRoles: []string{RoleMember}},
+		{inherit: false, maintainedTeam: "platform", expectedRoles: []string{RoleMember}},
+	}
+
+	logger, _ := test.NewNullLogger()
+	for _, tc := range tests {
+		maintainedTeam := tc.maintainedTeam
+		userTeamsClient := &mocks.TeamsClient{}
+		userTeamsClient.GetTeamMembershipBySlugStub = func(_ context.Context, _, slug, _ string) (*github.Membership, *github.Response, error) {
+			if slug == maintainedTeam {
+				return &github.Membership{Role: github.String(RoleMaintainer)}, nil, nil
+			}
+			return &github.Membership{Role: github.String(RoleMember)}, nil, nil
+		}
+		teamsClient := &mocks.TeamsClient{}
+		teamsClient.GetTeamBySlugStub = func(_ context.Context, _, slug string) (*github.Team, *github.Response, error) {
+			parents := map[string]string{"child": "middle", "middle": "platform"}
+			team := &github.Team{Slug: github.String(slug)}
+			if parent, ok := parents[slug]; ok {
+				team.Parent = &github.Team{Slug: github.String(parent)}
+			}
+			return team, nil, nil
+		}
+		manager := &Manager{
+			Config: &Config{
+				Policy: Policy{InheritMaintainership: tc.inherit},
+			},
+			CreateMaintainershipClient: func(string, string) (*MaintainershipClient, *github.User, error) {
+				return &MaintainershipClient{
+					TeamsClient: userTeamsClient,
+				}, &github.User{Login: github.String("fake-user")}, nil
+			},
+			Logger:      logger,
+			TeamsClient: teamsClient,
+		}
+
+		resolved, err := manager.resolveRoles("fake-token", "child", "fake-uuid")
+		require.NoError(t, err)
+		require.Equal(t, tc.expectedRoles, resolved.roles, tc.maintainedTeam)
+		require.Equal(t, tc.expectedAncestry, resolved.ancestry, tc.maintainedTeam)
+	}
+}
@@ -0,0 +1,258 @@
+/**
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package apis
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/google/go-github/v41/github"
+	"github.com/google/uuid"
+)
+
+const (
+	// ReconcileSync sets the repository access of the runner group to exactly the repositories of the team
+	ReconcileSync = "sync"
+	// ReconcileAdditive adds the repositories of the team to the runner group without removing any
+	ReconcileAdditive = "additive"
+	// ReconcileOff leaves the repository access of the runner group untouched
+	ReconcileOff = "off"
+
+	reconcileOperation = "reconcile"
+	reconcileUser      = "reconciler"
+)
+
+var reconcileModes = map[string]bool{
+	ReconcileSync:     true,
+	ReconcileAdditive: true,
+	ReconcileOff:      true,
+}
+
+// Reconcile configures the background reconciler that keeps the repository access of each runner group in line with
+// the repositories of the team it is named after. The reconciler is disabled when Interval is unset.
+type Reconcile struct {
+	Interval time.Duration     `yaml:"interval"`
+	DryRun   bool              `yaml:"dryRun"`
+	Mode     string            `yaml:"mode"`
+	Teams    map[string]string `yaml:"teams"`
+}
+
+// Validate verifies every configured mode is known
+func (r Reconcile) Validate() error {
+	if r.Mode != "" && !reconcileModes[r.Mode] {
+		return fmt.Errorf("unknown reconcile mode %s", r.Mode)
+	}
+	for team, mode := range r.Teams {
+		if !reconcileModes[mode] {
+			return fmt.Errorf("unknown reconcile mode %s for team %s", mode, team)
+		}
+	}
+	return nil
+}
+
+// ModeFor returns the mode configured for the team, falling back to the default mode, which is off when unset
+func (r Reconcile) ModeFor(team string) string {
+	if mode, ok := r.Teams[team]; ok {
+		return mode
+	}
+	if r.Mode == "" {
+		return ReconcileOff
+	}
+	return r.Mode
+}
+
+// repoDiff holds the repositories a reconciliation adds to and removes from a runner group
+type repoDiff struct {
+	added   []string
+	removed []string
+}
+
+// Reconciler runs a reconciliation every configured interval until the context is cancelled
+func (m *Manager) Reconciler(ctx context.Context) {
+	ticker := time.NewTicker(m.Config.Reconcile.Interval)
+	defer ticker.Stop()
+	for {
+		m.ReconcileAll(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ReconcileAll reconciles the repository access of every runner group named after a team whose mode is not off
+func (m *Manager) ReconcileAll(ctx context.Context) {
+	uuid := uuid.NewString()
+	m.Logger.WithField("uuid", uuid).Info("Listing runner groups to reconcile")
+	groups, err := m.listRunnerGroups(ctx)
+	if err != nil {
+		m.Logger.WithField("uuid", uuid).Errorf("Unable to list runner groups to reconcile: %v", err)
+		return
+	}
+	m.Logger.WithField("uuid", uuid).Debug("Listed runner groups to reconcile")
+
+	for _, group := range groups {
+		team, _, ok := m.Config.Groups.TeamFor(m.Config.Org, group.GetName())
+		mode := m.Config.Reconcile.ModeFor(team)
+		if group.GetDefault() || !ok || mode == ReconcileOff {
+			continue
+		}
+		if _, err := m.reconcileTeam(ctx, team, group.GetID(), mode, uuid); err != nil {
+			m.Logger.WithField("uuid", uuid).WithField("team", team).Errorf("Unable to reconcile runner group: %v", err)
+		}
+	}
+}
+
+// listRunnerGroups lists every runner group of the organization
+func (m *Manager) listRunnerGroups(ctx context.Context) ([]*github.RunnerGroup, error) {
+	var groups []*github.RunnerGroup
+	opts := &github.ListOptions{PerPage: 100}
+	for {
+		runnerGroups, resp, err := m.ActionsClient.ListOrganizationRunnerGroups(ctx, m.Config.Org, opts)
+		if err != nil {
+			return nil, gitHubError(resp, err)
+		}
+		groups = append(groups, runnerGroups.RunnerGroups...)
+		if resp.NextPage == 0 {
+			return groups, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// reconcileTeam brings the repository access of the runner group in line with the repositories of the team according
+// to the mode, only logging the difference in dry-run mode
+func (m *Manager) reconcileTeam(ctx context.Context, team string, groupID int64, mode, uuid string) (*repoDiff, error) {
+	m.Logger.WithField("uuid", uuid).WithField("team", team).Info("Listing repositories assigned to team")
+	teamRepos := map[string]int64{}
+	opts := &github.ListOptions{PerPage: 100}
+	for {
+		repos, resp, err := m.TeamsClient.ListTeamReposBySlug(ctx, m.Config.Org, team, opts)
+		if err != nil {
+			if resp != nil && resp.Response != nil && resp.StatusCode == http.StatusNotFound {
+				m.Logger.WithField("uuid", uuid).WithField("team", team).Debug("Runner group is not named after a team, skipping")
+				return &repoDiff{}, nil
+			}
+			return nil, fmt.Errorf("unable to retrieve team repos: %w", err)
+		}
+		for _, repo := range repos {
+			teamRepos[repo.GetName()] = repo.GetID()
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	m.Logger.WithField("uuid", uuid).WithField("team", team).Debug("Listed repositories assigned to team")
+
+	m.Logger.WithField("uuid", uuid).WithField("team", team).Info("Listing repositories assigned to runner group")
+	groupRepos := map[string]int64{}
+	opts = &github.ListOptions{PerPage: 100}
+	for {
+		repos, resp, err := m.ActionsClient.ListRepositoryAccessRunnerGroup(ctx, m.Config.Org, groupID, opts)
+		if err != nil {
+			return nil, fmt.Errorf("unable to retrieve runner group repos: %w", err)
+		}
+		for _, repo := range repos.Repositories {
+			groupRepos[repo.GetName()] = repo.GetID()
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	m.Logger.WithField("uuid", uuid).WithField("team", team).Debug("Listed repositories assigned to runner group")
+
+	diff := &repoDiff{}
+	desired := map[string]int64{}
+	for name, id := range teamRepos {
+		desired[name] = id
+		if _, ok := groupRepos[name]; !ok {
+			diff.added = append(diff.added, name)
+		}
+	}
+	for name, id := range groupRepos {
+		if _, ok := teamRepos[name]; ok {
+			continue
+		}
+		if mode == ReconcileAdditive {
+			desired[name] = id
+			continue
+		}
+		diff.removed = append(diff.removed, name)
+	}
+	sort.Strings(diff.added)
+	sort.Strings(diff.removed)
+	if len(diff.added) == 0 && len(diff.removed) == 0 {
+		m.Logger.WithField("uuid", uuid).WithField("team", team).Debug("Runner group is in sync with team")
+		return diff, nil
+	}
+
+	if m.Config.Reconcile.DryRun {
+		m.Logger.WithField("uuid", uuid).WithField("team", team).Infof("Dry run, would add repositories %v and remove repositories %v", diff.added, diff.removed)
+		return diff, nil
+	}
+
+	var before, after []string
+	for name := range groupRepos {
+		before = append(before, name)
+	}
+	ids := []int64{}
+	for name, id := range desired {
+		after = append(after, name)
+		ids = append(ids, id)
+	}
+	sort.Strings(before)
+	sort.Strings(after)
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	m.Logger.WithField("uuid", uuid).WithField("team", team).Infof("Adding repositories %v and removing repositories %v", diff.added, diff.removed)
+	resp, err := m.ActionsClient.SetRepositoryAccessRunnerGroup(ctx, m.Config.Org, groupID, github.SetRepoAccessRunnerGroupRequest{
+		SelectedRepositoryIDs: ids,
+	})
+	code := http.StatusOK
+	if resp != nil && resp.Response != nil {
+		code = resp.StatusCode
+	} else if err != nil {
+		code = http.StatusInternalServerError
+	}
+	m.auditReconcile(team, uuid, before, after, code)
+	if err != nil {
+		return nil, fmt.Errorf("unable to set repositories for runner group: %w", err)
+	}
+	m.Logger.WithField("uuid", uuid).WithField("team", team).Debug("Reconciled runner group")
+	return diff, nil
+}
+
+// auditReconcile records the change made by the reconciler when an audit log is configured
+func (m *Manager) auditReconcile(team, uuid string, before, after []string, code int) {
+	if m.AuditLog == nil {
+		return
+	}
+	if before == nil {
+		before = []string{}
+	}
+	if after == nil {
+		after = []string{}
+	}
+	err := m.AuditLog.Write(&AuditEvent{
+		Time:        time.Now().UTC(),
+		RequestID:   uuid,
+		Org:         m.Config.Org,
+		User:        reconcileUser,
+		Team:        team,
+		Operation:   reconcileOperation,
+		ReposBefore: before,
+		ReposAfter:  after,
+		Code:        code,
+	})
+	if err != nil {
+		m.Logger.WithField("uuid", uuid).WithField("team", team).Errorf("Unable to write audit event: %v", err)
+	}
+}
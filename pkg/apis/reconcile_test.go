@@ -0,0 +1,167 @@
+/**
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package apis
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-github/v41/github"
+	"github.com/lindluni/actions-runner-manager/pkg/apis/mocks"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReconcile_Validate(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, Reconcile{}.Validate())
+	require.EqualError(t, Reconcile{Mode: "mirror"}.Validate(), "unknown reconcile mode mirror")
+	require.EqualError(t, Reconcile{Teams: map[string]string{"fake-team": "mirror"}}.Validate(), "unknown reconcile mode mirror for team fake-team")
+
+	reconcile := Reconcile{Mode: ReconcileAdditive, Teams: map[string]string{"fake-team": ReconcileOff}}
+	require.Equal(t, ReconcileOff, reconcile.ModeFor("fake-team"))
+	require.Equal(t, ReconcileAdditive, reconcile.ModeFor("other-team"))
+	require.Equal(t, ReconcileOff, Reconcile{}.ModeFor("other-team"))
+}
+
+func TestReconcileAll(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		mode        string
+		dryRun      bool
+		expectedIDs []int64
+	}{
+		{name: "sync", mode: ReconcileSync, expectedIDs: []int64{1, 2}},
+		{name: "additive", mode: ReconcileAdditive, expectedIDs: []int64{1, 2, 3}},
+		{name: "dry run", mode: ReconcileSync, dryRun: true},
+		{name: "off", mode: ReconcileOff},
+	}
+
+	logger, _ := test.NewNullLogger()
+	for _, tc := range tests {
+		actionsClient := &mocks.ActionsClient{}
+		actionsClient.ListOrganizationRunnerGroupsReturns(&github.RunnerGroups{
+			RunnerGroups: []*github.RunnerGroup{
+				{ID: github.Int64(1), Name: github.String("Default"), Default: github.Bool(true)},
+				{ID: github.Int64(2), Name: github.String("fake-team")},
+			},
+		}, &github.Response{}, nil)
+		actionsClient.ListRepositoryAccessRunnerGroupReturns(&github.ListRepositories{
+			Repositories: []*github.Repository{
+				{ID: github.Int64(1), Name: github.String("kept-repo")},
+				{ID: github.Int64(3), Name: github.String("removed-repo")},
+			},
+		}, &github.Response{}, nil)
+		actionsClient.SetRepositoryAccessRunnerGroupReturns(&github.Response{Response: &http.Response{StatusCode: http.StatusNoContent}}, nil)
+		teamsClient := &mocks.TeamsClient{}
+		teamsClient.ListTeamReposBySlugReturns([]*github.Repository{
+			{ID: github.Int64(1), Name: github.String("kept-repo")},
+			{ID: github.Int64(2), Name: github.String("added-repo")},
+		}, &github.Response{}, nil)
+		sink, err := NewFileAuditSink(filepath.Join(t.TempDir(), "audit.jsonl"))
+		require.NoError(t, err, tc.name)
+		manager := &Manager{
+			ActionsClient: actionsClient,
+			AuditLog:      sink,
+			Config: &Config{
+				Org: "fake-org",
+				Reconcile: Reconcile{
+					DryRun: tc.dryRun,
+					Teams:  map[string]string{"fake-team": tc.mode},
+				},
+			},
+			Logger:      logger,
+			TeamsClient: teamsClient,
+		}
+
+		manager.ReconcileAll(context.Background())
+		events, err := sink.Query(&AuditFilter{})
+		require.NoError(t, err, tc.name)
+		require.NoError(t, sink.Close(), tc.name)
+
+		if tc.mode == ReconcileOff {
+			require.Equal(t, 0, teamsClient.ListTeamReposBySlugCallCount(), tc.name)
+		} else {
+			_, org, slug, _ := teamsClient.ListTeamReposBySlugArgsForCall(0)
+			require.Equal(t, "fake-org", org, tc.name)
+			require.Equal(t, "fake-team", slug, tc.name)
+			_, _, groupID, _ := actionsClient.ListRepositoryAccessRunnerGroupArgsForCall(0)
+			require.Equal(t, int64(2), groupID, tc.name)
+		}
+		if tc.expectedIDs == nil {
+			require.Equal(t, 0, actionsClient.SetRepositoryAccessRunnerGroupCallCount(), tc.name)
+			require.Empty(t, events, tc.name)
+			continue
+		}
+		require.Equal(t, 1, actionsClient.SetRepositoryAccessRunnerGroupCallCount(), tc.name)
+		_, _, groupID, request := actionsClient.SetRepositoryAccessRunnerGroupArgsForCall(0)
+		require.Equal(t, int64(2), groupID, tc.name)
+		require.Equal(t, tc.expectedIDs, request.SelectedRepositoryIDs, tc.name)
+		require.Len(t, events, 1, tc.name)
+		require.Equal(t, reconcileUser, events[0].User, tc.name)
+		require.Equal(t, []string{"kept-repo", "removed-repo"}, events[0].ReposBefore, tc.name)
+		require.Equal(t, http.StatusNoContent, events[0].Code, tc.name)
+	}
+}
+
+func TestReconcileTeam_TeamNotFound(t *testing.T) {
+	t.Parallel()
+
+	actionsClient := &mocks.ActionsClient{}
+	teamsClient := &mocks.TeamsClient{}
+	teamsClient.ListTeamReposBySlugReturns(nil, &github.Response{Response: &http.Response{StatusCode: http.StatusNotFound}}, &github.ErrorResponse{})
+	logger, _ := test.NewNullLogger()
+	manager := &Manager{
+		ActionsClient: actionsClient,
+		Config:        &Config{Org: "fake-org"},
+		Logger:        logger,
+		TeamsClient:   teamsClient,
+	}
+
+	diff, err := manager.reconcileTeam(context.Background(), "fake-team", 2, ReconcileSync, "fake-uuid")
+	require.NoError(t, err)
+	require.Empty(t, diff.added)
+	require.Empty(t, diff.removed)
+	require.Equal(t, 0, actionsClient.ListRepositoryAccessRunnerGroupCallCount())
+}
+
+func TestReconcileTeam_NoRepositories(t *testing.T) {
+	t.Parallel()
+
+	actionsClient := &mocks.ActionsClient{}
+	actionsClient.ListRepositoryAccessRunnerGroupReturns(&github.ListRepositories{
+		Repositories: []*github.Repository{
+			{ID: github.Int64(1), Name: github.String("removed-repo")},
+		},
+	}, &github.Response{}, nil)
+	actionsClient.SetRepositoryAccessRunnerGroupReturns(&github.Response{Response: &http.Response{StatusCode: http.StatusNoContent}}, nil)
+	teamsClient := &mocks.TeamsClient{}
+	teamsClient.ListTeamReposBySlugReturns([]*github.Repository{}, &github.Response{}, nil)
+	logger, _ := test.NewNullLogger()
+	manager := &Manager{
+		ActionsClient: actionsClient,
+		Config:        &Config{Org: "fake-org"},
+		Logger:        logger,
+		TeamsClient:   teamsClient,
+	}
+
+	diff, err := manager.reconcileTeam(context.Background(), "fake-team", 2, ReconcileSync, "fake-uuid")
+	require.NoError(t, err)
+	require.Empty(t, diff.added)
+	require.Equal(t, []string{"removed-repo"}, diff.removed)
+	require.Equal(t, 1, actionsClient.SetRepositoryAccessRunnerGroupCallCount())
+	_, _, groupID, request := actionsClient.SetRepositoryAccessRunnerGroupArgsForCall(0)
+	require.Equal(t, int64(2), groupID)
+	// GitHub rejects a null list of repositories, clearing the runner group requires an empty one
+	body, err := json.Marshal(request)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"selected_repository_ids":[]}`, string(body))
+}
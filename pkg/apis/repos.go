@@ -15,94 +15,90 @@ import (
 	"github.com/google/go-github/v41/github"
 )
 
+const (
+	// RepoStatusAdded reports a repository was added to the runner group
+	RepoStatusAdded = "added"
+	// RepoStatusRemoved reports a repository was removed from the runner group
+	RepoStatusRemoved = "removed"
+	// RepoStatusAlreadyPresent reports a repository was already assigned to the runner group
+	RepoStatusAlreadyPresent = "already-present"
+	// RepoStatusNotPresent reports a repository was not assigned to the runner group
+	RepoStatusNotPresent = "not-present"
+	// RepoStatusNotInTeam reports the team does not have access to a repository
+	RepoStatusNotInTeam = "not-in-team"
+	// RepoStatusNotFound reports a repository does not exist in the organization
+	RepoStatusNotFound = "not-found"
+	// RepoStatusFailed reports the GitHub API call for a repository failed with the status in Code
+	RepoStatusFailed = "failed"
+)
+
+// repoResult is the outcome of adding or removing a single repository
+type repoResult struct {
+	Repo      string    `json:"repo"`
+	Status    string    `json:"status"`
+	Code      int       `json:"code,omitempty"`
+	Error     string    `json:"error,omitempty"`
+	ErrorCode ErrorCode `json:"errorCode,omitempty"`
+	Retryable bool      `json:"retryable,omitempty"`
+}
+
+// failed reports whether the repository was left in a state other than the one requested
+func (r *repoResult) failed() bool {
+	return r.Status == RepoStatusNotInTeam || r.Status == RepoStatusNotFound || r.Status == RepoStatusFailed
+}
+
 // DoReposAdd    Add new repositories to an existing GitHub Actions organization runner group
 // @Summary      Add new repositories to an existing GitHub Actions organization runner group
-// @Description  Adds new repositories to an existing GitHub Actions organization named with the team slug
+// @Description  Adds new repositories to an existing GitHub Actions organization named with the team slug. Every repository is attempted and its result reported, with a 207 status when any repository could not be added.
 // @Tags         Repos
 // @Produce      json
-// @Param        team   query     string    true  "Canonical **slug** of the GitHub team"
-// @Param        repos  query     []string  true  "Comma-seperated list of repository slugs"
-// @Success      200    {object}  JSONResultSuccess{Code=int,Response=string}
-// @Router       /repos-add [patch]
+// @Param        team     query     string    true   "Canonical **slug** of the GitHub team"
+// @Param        group    query     string    false  "Suffix of the runner group of the team, selects its default runner group when unset"
+// @Param        dry_run  query     bool      false  "Validate the request and return the change it would make without making it"
+// @Param        repos    query     []string  true   "Comma-seperated list of repository slugs"
+// @Success      200      {object}  JSONResultSuccess{Code=int,Response=[]repoResult}
+// @Success      207      {object}  JSONResultSuccess{Code=int,Response=[]repoResult}
+// @Router       /v1/repos-add [patch]
 // @Security     ApiKeyAuth
 func (m *Manager) DoReposAdd(c *gin.Context) {
 	uuid := requestid.Get(c)
+	team := c.GetString(teamKey)
 
-	m.Logger.Info("Retrieving team parameter")
-	team := c.Query("team")
-	if team == "" {
-		c.JSON(http.StatusBadRequest, &JSONResultError{
-			Code:  http.StatusBadRequest,
-			Error: "Missing required parameter: team",
-		})
-		return
-	}
-	m.Logger.WithField("uuid", uuid).WithField("team", team).Debug("Retrieving repo parameter")
-
-	m.Logger.WithField("uuid", uuid).WithField("team", team).Info("Retrieving repo parameter")
-	repos := c.Query("repos")
-	if repos == "" {
-		c.JSON(http.StatusBadRequest, &JSONResultError{
-			Code:  http.StatusBadRequest,
-			Error: "Missing required parameter: repos",
-		})
+	m.Logger.WithField("uuid", uuid).WithField("team", team).Info("Retrieving group and dry_run parameters")
+	suffix, ok := m.retrieveGroupSuffix(c)
+	if !ok {
 		return
 	}
-	repoNames := strings.Split(repos, ",")
-	m.Logger.WithField("uuid", uuid).WithField("team", team).Debug("Retrieving repo parameter")
-
-	m.Logger.WithField("uuid", uuid).WithField("team", team).Info("Retrieving Authorization header")
-	token := c.GetHeader("Authorization")
-	if token == "" {
-		c.JSON(http.StatusForbidden, &JSONResultError{
-			Code:  http.StatusForbidden,
-			Error: "Missing Authorization header",
-		})
+	dryRun, ok := retrieveDryRun(c)
+	if !ok {
 		return
 	}
-	m.Logger.WithField("uuid", uuid).WithField("team", team).Debug("Retrieved Authorization header")
+	m.Logger.WithField("uuid", uuid).WithField("team", team).Debug("Retrieved group and dry_run parameters")
 
-	m.Logger.WithField("uuid", uuid).WithField("team", team).Info("Verifying maintainership")
-	isMaintainer, err := m.verifyMaintainership(token, team, uuid)
-	if err != nil {
-		c.JSON(http.StatusForbidden, &JSONResultError{
-			Code:  http.StatusForbidden,
-			Error: fmt.Sprintf("Unable to validate user is a team maintainer: %v", err),
-		})
-		return
-	}
-	if !isMaintainer {
-		c.JSON(http.StatusUnauthorized, &JSONResultError{
-			Code:  http.StatusUnauthorized,
-			Error: "User is not a maintainer of the team",
-		})
+	m.Logger.WithField("uuid", uuid).WithField("team", team).Info("Retrieving repo parameter")
+	repoNames := parseRepoNames(c.Query("repos"))
+	if len(repoNames) == 0 {
+		writeError(c, http.StatusBadRequest, ErrorCodeBadRequest, "Missing required parameter: repos")
 		return
 	}
-	m.Logger.WithField("uuid", uuid).WithField("team", team).Debug("Verified maintainership")
+	m.Logger.WithField("uuid", uuid).WithField("team", team).Debug("Retrieving repo parameter")
 
 	m.Logger.WithField("uuid", uuid).WithField("team", team).Info("Retrieving runner group ID")
-	groupID, statusCode, err := m.retrieveGroupID(team, uuid)
+	groupID, err := m.retrieveGroupID(team, suffix, uuid)
 	if err != nil {
-		c.JSON(statusCode, &JSONResultError{
-			Code:  statusCode,
-			Error: fmt.Sprintf("Unable to retrieve group ID: %v", err),
-		})
+		writeAPIError(c, err, fmt.Sprintf("Unable to retrieve group ID: %v", err))
 		return
 	}
 	m.Logger.WithField("uuid", uuid).WithField("team", team).Debug("Retrieved runner group ID")
 
 	ctx := context.Background()
 	m.Logger.WithField("uuid", uuid).WithField("team", team).Info("Listing repositories assigned to team")
-	repoIDs := map[string]int64{}
 	var assignedRepos []*github.Repository
 	opts := &github.ListOptions{PerPage: 100}
 	for {
 		teamRepos, resp, err := m.TeamsClient.ListTeamReposBySlug(ctx, m.Config.Org, team, opts)
 		if err != nil {
-			c.JSON(resp.StatusCode, &JSONResultError{
-				Code:  resp.StatusCode,
-				Error: fmt.Sprintf("Unable to retrieve team repos: %v", err),
-			})
+			writeAPIError(c, gitHubError(resp, err), fmt.Sprintf("Unable to retrieve team repos: %v", err))
 			return
 		}
 		assignedRepos = append(assignedRepos, teamRepos...)
@@ -113,158 +109,136 @@ func (m *Manager) DoReposAdd(c *gin.Context) {
 	}
 	m.Logger.WithField("uuid", uuid).WithField("team", team).Debug("Listed repositories assigned to team")
 
-	m.Logger.WithField("uuid", uuid).WithField("team", team).Info("Mapping retrieved team repos to submitted repos")
-	for _, name := range repoNames {
-		m.Logger.Infof("Checking if team %s has access to repo %s", team, name)
-		id, err := findRepoID(name, assignedRepos)
-		if err != nil {
-			c.JSON(http.StatusNotFound, &JSONResultError{
-				Code:  http.StatusNotFound,
-				Error: fmt.Sprintf("Repo %s not found in team %s: %v", name, team, err),
-			})
-			return
-		}
-		repoIDs[name] = id
+	m.Logger.WithField("uuid", uuid).WithField("team", team).Info("Listing repositories assigned to runner group")
+	groupRepos, resp, err := m.listGroupRepos(ctx, *groupID)
+	if err != nil {
+		writeAPIError(c, gitHubError(resp, err), fmt.Sprintf("Unable to retrieve runner group repos: %v", err))
+		return
 	}
-	m.Logger.WithField("uuid", uuid).WithField("team", team).Debug("Mapped retrieved team repos to submitted repos")
+	m.Logger.WithField("uuid", uuid).WithField("team", team).Debug("Listed repositories assigned to runner group")
 
 	m.Logger.WithField("uuid", uuid).WithField("team", team).Info("Adding repositories to runner group")
-	for name, repoID := range repoIDs {
-		m.Logger.Infof("Adding repo %s to runner group %s", name, team)
+	var results []*repoResult
+	for _, name := range repoNames {
+		if _, ok := groupRepos[name]; ok {
+			results = append(results, &repoResult{Repo: name, Status: RepoStatusAlreadyPresent})
+			continue
+		}
+		repoID, err := findRepoID(name, assignedRepos)
+		if err != nil {
+			m.Logger.WithField("uuid", uuid).WithField("team", team).Infof("Team does not have access to repo %s", name)
+			results = append(results, m.missingRepoResult(ctx, name, RepoStatusNotInTeam))
+			continue
+		}
+		if dryRun {
+			results = append(results, &repoResult{Repo: name, Status: RepoStatusAdded})
+			continue
+		}
+		m.Logger.WithField("uuid", uuid).WithField("team", team).Infof("Adding repo %s to runner group", name)
 		resp, err := m.ActionsClient.AddRepositoryAccessRunnerGroup(ctx, m.Config.Org, *groupID, repoID)
 		if err != nil {
-			c.JSON(resp.StatusCode, &JSONResultError{
-				Code:  resp.StatusCode,
-				Error: fmt.Sprintf("Unable to add repo %s to runner group %s: %v", name, team, err),
-			})
-			return
+			m.Logger.WithField("uuid", uuid).WithField("team", team).Errorf("Unable to add repo %s to runner group: %v", name, err)
+			results = append(results, failedRepoResult(name, resp, err))
+			continue
 		}
+		results = append(results, &repoResult{Repo: name, Status: RepoStatusAdded})
 	}
 	m.Logger.WithField("uuid", uuid).WithField("team", team).Debug("Added repositories to runner group")
 
-	c.JSON(http.StatusOK, &JSONResultSuccess{
-		Code:     http.StatusOK,
-		Response: "Successfully added repositories to runner group",
-	})
+	if dryRun {
+		writeDryRun(c, repoResultsCode(results), &dryRunDiff{
+			ReposAdded: reposWithStatus(results, RepoStatusAdded),
+			Results:    results,
+		})
+		return
+	}
+	writeRepoResults(c, results)
 }
 
 // DoReposRemove    Remove existing repositories from an existing GitHub Actions organization runner group
 // @Summary      Remove existing repositories from an existing GitHub Actions organization runner group
-// @Description  Removes existing repositories to an existing GitHub Actions organization named with the team slug
+// @Description  Removes existing repositories to an existing GitHub Actions organization named with the team slug. Every repository is attempted and its result reported, with a 207 status when any repository could not be removed.
 // @Tags         Repos
 // @Produce      json
-// @Param        team   query     string    true  "Canonical **slug** of the GitHub team"
-// @Param        repos  query     []string  true  "Comma-seperated list of repository slugs"
-// @Success      200    {object}  JSONResultSuccess{Code=int,Response=string}
-// @Router       /repos-remove [patch]
+// @Param        team     query     string    true   "Canonical **slug** of the GitHub team"
+// @Param        group    query     string    false  "Suffix of the runner group of the team, selects its default runner group when unset"
+// @Param        dry_run  query     bool      false  "Validate the request and return the change it would make without making it"
+// @Param        repos    query     []string  true   "Comma-seperated list of repository slugs"
+// @Success      200      {object}  JSONResultSuccess{Code=int,Response=[]repoResult}
+// @Success      207      {object}  JSONResultSuccess{Code=int,Response=[]repoResult}
+// @Router       /v1/repos-remove [patch]
 // @Security     ApiKeyAuth
 func (m *Manager) DoReposRemove(c *gin.Context) {
 	uuid := requestid.Get(c)
+	team := c.GetString(teamKey)
 
-	m.Logger.Info("Retrieving team parameter")
-	team := c.Query("team")
-	if team == "" {
-		c.JSON(http.StatusBadRequest, &JSONResultError{
-			Code:  http.StatusBadRequest,
-			Error: "Missing required parameter: team",
-		})
+	m.Logger.WithField("uuid", uuid).WithField("team", team).Info("Retrieving group and dry_run parameters")
+	suffix, ok := m.retrieveGroupSuffix(c)
+	if !ok {
 		return
 	}
-	m.Logger.WithField("uuid", uuid).WithField("team", team).Debug("Retrieved team parameter")
-
-	m.Logger.WithField("uuid", uuid).WithField("team", team).Info("Retrieving repos parameter")
-	repos := c.Query("repos")
-	if repos == "" {
-		c.JSON(http.StatusBadRequest, &JSONResultError{
-			Code:  http.StatusBadRequest,
-			Error: "Missing required parameter: repos",
-		})
-		return
-	}
-	repoNames := strings.Split(repos, ",")
-	m.Logger.WithField("uuid", uuid).WithField("team", team).Debug("Retrieved repo parameter")
-
-	m.Logger.WithField("uuid", uuid).WithField("team", team).Info("Retrieving Authorization header")
-	token := c.GetHeader("Authorization")
-	if token == "" {
-		c.JSON(http.StatusForbidden, &JSONResultError{
-			Code:  http.StatusForbidden,
-			Error: "Missing Authorization header",
-		})
+	dryRun, ok := retrieveDryRun(c)
+	if !ok {
 		return
 	}
-	m.Logger.WithField("uuid", uuid).WithField("team", team).Debug("Retrieved Authorization header")
+	m.Logger.WithField("uuid", uuid).WithField("team", team).Debug("Retrieved group and dry_run parameters")
 
-	m.Logger.WithField("uuid", uuid).WithField("team", team).Info("Verifying maintainership")
-	isMaintainer, err := m.verifyMaintainership(token, team, uuid)
-	if err != nil {
-		c.JSON(http.StatusForbidden, &JSONResultError{
-			Code:  http.StatusForbidden,
-			Error: fmt.Sprintf("Unable to validate user is a team maintainer: %v", err),
-		})
-		return
-	}
-	if !isMaintainer {
-		c.JSON(http.StatusUnauthorized, &JSONResultError{
-			Code:  http.StatusUnauthorized,
-			Error: "User is not a maintainer of the team",
-		})
+	m.Logger.WithField("uuid", uuid).WithField("team", team).Info("Retrieving repos parameter")
+	repoNames := parseRepoNames(c.Query("repos"))
+	if len(repoNames) == 0 {
+		writeError(c, http.StatusBadRequest, ErrorCodeBadRequest, "Missing required parameter: repos")
 		return
 	}
-	m.Logger.WithField("uuid", uuid).WithField("team", team).Debug("Verified maintainership")
+	m.Logger.WithField("uuid", uuid).WithField("team", team).Debug("Retrieved repo parameter")
 
 	m.Logger.WithField("uuid", uuid).WithField("team", team).Info("Retrieving runner group ID")
-	groupID, statusCode, err := m.retrieveGroupID(team, uuid)
+	groupID, err := m.retrieveGroupID(team, suffix, uuid)
 	if err != nil {
-		c.JSON(statusCode, &JSONResultError{
-			Code:  statusCode,
-			Error: fmt.Sprintf("Unable to retrieve group ID: %v", err),
-		})
+		writeAPIError(c, err, fmt.Sprintf("Unable to retrieve group ID: %v", err))
 		return
 	}
 	m.Logger.WithField("uuid", uuid).WithField("team", team).Debug("Retrieved runner group ID")
 
 	ctx := context.Background()
-	m.Logger.WithField("uuid", uuid).WithField("team", team).Info("Retrieving repository ID's")
-	repoIDs := map[string]int64{}
-	for _, name := range repoNames {
-		repo, resp, err := m.RepositoriesClient.Get(ctx, m.Config.Org, name)
-		if err != nil {
-			if resp != nil && resp.StatusCode == http.StatusNotFound {
-				c.JSON(resp.StatusCode, &JSONResultError{
-					Code:  resp.StatusCode,
-					Error: fmt.Sprintf("Repository %s not found", name),
-				})
-				return
-			}
-			c.JSON(http.StatusInternalServerError, &JSONResultError{
-				Code:  http.StatusInternalServerError,
-				Error: fmt.Sprintf("Unable to retrieve repository %s: %v", name, err),
-			})
-			return
-		}
-		repoIDs[name] = repo.GetID()
+	m.Logger.WithField("uuid", uuid).WithField("team", team).Info("Listing repositories assigned to runner group")
+	groupRepos, resp, err := m.listGroupRepos(ctx, *groupID)
+	if err != nil {
+		writeAPIError(c, gitHubError(resp, err), fmt.Sprintf("Unable to retrieve runner group repos: %v", err))
+		return
 	}
-	m.Logger.WithField("uuid", uuid).WithField("team", team).Debug("Retrieved repository ID's")
+	m.Logger.WithField("uuid", uuid).WithField("team", team).Debug("Listed repositories assigned to runner group")
 
 	m.Logger.WithField("uuid", uuid).WithField("team", team).Info("Removing repositories from runner group")
-	for name, repoID := range repoIDs {
-		m.Logger.Infof("Removing repo %s from runner group %s", name, team)
+	var results []*repoResult
+	for _, name := range repoNames {
+		repoID, ok := groupRepos[name]
+		if !ok {
+			results = append(results, m.missingRepoResult(ctx, name, RepoStatusNotPresent))
+			continue
+		}
+		if dryRun {
+			results = append(results, &repoResult{Repo: name, Status: RepoStatusRemoved})
+			continue
+		}
+		m.Logger.WithField("uuid", uuid).WithField("team", team).Infof("Removing repo %s from runner group", name)
 		resp, err := m.ActionsClient.RemoveRepositoryAccessRunnerGroup(ctx, m.Config.Org, *groupID, repoID)
 		if err != nil {
-			c.JSON(resp.StatusCode, &JSONResultError{
-				Code:  resp.StatusCode,
-				Error: fmt.Sprintf("Unable to remove repo %s from runner group %s: %v", name, team, err),
-			})
-			return
+			m.Logger.WithField("uuid", uuid).WithField("team", team).Errorf("Unable to remove repo %s from runner group: %v", name, err)
+			results = append(results, failedRepoResult(name, resp, err))
+			continue
 		}
+		results = append(results, &repoResult{Repo: name, Status: RepoStatusRemoved})
 	}
 	m.Logger.WithField("uuid", uuid).WithField("team", team).Debug("Removed repositories from runner group")
 
-	c.JSON(http.StatusOK, &JSONResultSuccess{
-		Code:     http.StatusOK,
-		Response: "Successfully removed repositories from runner group",
-	})
+	if dryRun {
+		writeDryRun(c, repoResultsCode(results), &dryRunDiff{
+			ReposRemoved: reposWithStatus(results, RepoStatusRemoved),
+			Results:      results,
+		})
+		return
+	}
+	writeRepoResults(c, results)
 }
 
 // DoReposSet       Replaces all existing repositories in an existing GitHub Actions organization runner group with a new set of repositories
@@ -272,65 +246,35 @@ func (m *Manager) DoReposRemove(c *gin.Context) {
 // @Description  Replaces all existing repositories in an existing GitHub Actions organization named with the team slug with a new set of repositories
 // @Tags         Repos
 // @Produce      json
-// @Param        team   query     string    true  "Canonical **slug** of the GitHub team"
-// @Param        repos  query     []string  true  "Comma-seperated list of repository slugs"
-// @Success      200    {object}  JSONResultSuccess{Code=int,Response=string}
-// @Router       /repos-set [patch]
+// @Param        team     query     string    true   "Canonical **slug** of the GitHub team"
+// @Param        group    query     string    false  "Suffix of the runner group of the team, selects its default runner group when unset"
+// @Param        dry_run  query     bool      false  "Validate the request and return the change it would make without making it"
+// @Param        repos    query     []string  true   "Comma-seperated list of repository slugs"
+// @Success      200      {object}  JSONResultSuccess{Code=int,Response=string}
+// @Router       /v1/repos-set [patch]
 // @Security     ApiKeyAuth
 func (m *Manager) DoReposSet(c *gin.Context) {
 	uuid := requestid.Get(c)
+	team := c.GetString(teamKey)
 
-	m.Logger.Info("Retrieving team parameter")
-	team := c.Query("team")
-	if team == "" {
-		c.JSON(http.StatusBadRequest, &JSONResultError{
-			Code:  http.StatusBadRequest,
-			Error: "Missing required parameter: team",
-		})
-		return
-	}
-	m.Logger.WithField("uuid", uuid).WithField("team", team).Debug("Retrieved team parameter")
-
-	m.Logger.WithField("uuid", uuid).WithField("team", team).Info("Retrieving assignedRepos parameter")
-	repos := c.Query("repos")
-	if repos == "" {
-		c.JSON(http.StatusBadRequest, &JSONResultError{
-			Code:  http.StatusBadRequest,
-			Error: "Missing required parameter: repos",
-		})
+	m.Logger.WithField("uuid", uuid).WithField("team", team).Info("Retrieving group and dry_run parameters")
+	suffix, ok := m.retrieveGroupSuffix(c)
+	if !ok {
 		return
 	}
-	repoNames := strings.Split(repos, ",")
-	m.Logger.WithField("uuid", uuid).WithField("team", team).Debug("Retrieved repo parameter")
-
-	m.Logger.WithField("uuid", uuid).WithField("team", team).Info("Retrieving Authorization header")
-	token := c.GetHeader("Authorization")
-	if token == "" {
-		c.JSON(http.StatusForbidden, &JSONResultError{
-			Code:  http.StatusForbidden,
-			Error: "Missing Authorization header",
-		})
+	dryRun, ok := retrieveDryRun(c)
+	if !ok {
 		return
 	}
-	m.Logger.WithField("uuid", uuid).WithField("team", team).Debug("Retrieved Authorization header")
+	m.Logger.WithField("uuid", uuid).WithField("team", team).Debug("Retrieved group and dry_run parameters")
 
-	m.Logger.WithField("uuid", uuid).WithField("team", team).Info("Verifying maintainership")
-	isMaintainer, err := m.verifyMaintainership(token, team, uuid)
-	if err != nil {
-		c.JSON(http.StatusForbidden, &JSONResultError{
-			Code:  http.StatusForbidden,
-			Error: fmt.Sprintf("Unable to validate user is a team maintainer: %v", err),
-		})
-		return
-	}
-	if !isMaintainer {
-		c.JSON(http.StatusUnauthorized, &JSONResultError{
-			Code:  http.StatusUnauthorized,
-			Error: "User is not a maintainer of the team",
-		})
+	m.Logger.WithField("uuid", uuid).WithField("team", team).Info("Retrieving assignedRepos parameter")
+	repoNames := parseRepoNames(c.Query("repos"))
+	if len(repoNames) == 0 {
+		writeError(c, http.StatusBadRequest, ErrorCodeBadRequest, "Missing required parameter: repos")
 		return
 	}
-	m.Logger.WithField("uuid", uuid).WithField("team", team).Debug("Verified maintainership")
+	m.Logger.WithField("uuid", uuid).WithField("team", team).Debug("Retrieved repo parameter")
 
 	ctx := context.Background()
 	m.Logger.WithField("uuid", uuid).WithField("team", team).Info("Listing repositories assigned to team")
@@ -339,10 +283,7 @@ func (m *Manager) DoReposSet(c *gin.Context) {
 	for {
 		teamRepos, resp, err := m.TeamsClient.ListTeamReposBySlug(ctx, m.Config.Org, team, opts)
 		if err != nil {
-			c.JSON(resp.StatusCode, &JSONResultError{
-				Code:  resp.StatusCode,
-				Error: fmt.Sprintf("Unable to retrieve team assignedRepos: %v", err),
-			})
+			writeAPIError(c, gitHubError(resp, err), fmt.Sprintf("Unable to retrieve team assignedRepos: %v", err))
 			return
 		}
 		assignedRepos = append(assignedRepos, teamRepos...)
@@ -359,10 +300,7 @@ func (m *Manager) DoReposSet(c *gin.Context) {
 		m.Logger.Infof("Checking if team %s has access to repo %s", team, name)
 		id, err := findRepoID(name, assignedRepos)
 		if err != nil {
-			c.JSON(http.StatusNotFound, &JSONResultError{
-				Code:  http.StatusNotFound,
-				Error: fmt.Sprintf("Repo %s not found in team %s: %v", name, team, err),
-			})
+			writeError(c, http.StatusNotFound, ErrorCodeRepoNotInTeam, fmt.Sprintf("Repo %s not found in team %s: %v", name, team, err))
 			return
 		}
 		repoIDs = append(repoIDs, id)
@@ -370,25 +308,49 @@ func (m *Manager) DoReposSet(c *gin.Context) {
 	m.Logger.WithField("uuid", uuid).WithField("team", team).Debug("Mapped retrieved team assignedRepos to submitted assignedRepos")
 
 	m.Logger.WithField("uuid", uuid).WithField("team", team).Info("Retrieving runner group ID")
-	groupID, statusCode, err := m.retrieveGroupID(team, uuid)
+	groupID, err := m.retrieveGroupID(team, suffix, uuid)
 	if err != nil {
-		c.JSON(statusCode, &JSONResultError{
-			Code:  statusCode,
-			Error: fmt.Sprintf("Unable to retrieve group ID: %v", err),
-		})
+		writeAPIError(c, err, fmt.Sprintf("Unable to retrieve group ID: %v", err))
 		return
 	}
 	m.Logger.WithField("uuid", uuid).WithField("team", team).Debug("Retrieved runner group ID")
 
+	if dryRun {
+		m.Logger.WithField("uuid", uuid).WithField("team", team).Info("Listing repositories assigned to runner group")
+		groupRepos, resp, err := m.listGroupRepos(ctx, *groupID)
+		if err != nil {
+			writeAPIError(c, gitHubError(resp, err), fmt.Sprintf("Unable to retrieve runner group repos: %v", err))
+			return
+		}
+		m.Logger.WithField("uuid", uuid).WithField("team", team).Debug("Listed repositories assigned to runner group")
+
+		diff := &dryRunDiff{}
+		desired := map[string]bool{}
+		for _, name := range repoNames {
+			if desired[name] {
+				continue
+			}
+			desired[name] = true
+			if _, ok := groupRepos[name]; !ok {
+				diff.ReposAdded = append(diff.ReposAdded, name)
+			}
+		}
+		for name := range groupRepos {
+			if !desired[name] {
+				diff.ReposRemoved = append(diff.ReposRemoved, name)
+			}
+		}
+		m.Logger.WithField("uuid", uuid).WithField("team", team).Infof("Dry run, would add repositories %v and remove repositories %v", diff.ReposAdded, diff.ReposRemoved)
+		writeDryRun(c, http.StatusOK, diff)
+		return
+	}
+
 	m.Logger.WithField("uuid", uuid).WithField("team", team).Info("Adding repositories to runner group")
 	resp, err := m.ActionsClient.SetRepositoryAccessRunnerGroup(ctx, m.Config.Org, *groupID, github.SetRepoAccessRunnerGroupRequest{
 		SelectedRepositoryIDs: repoIDs,
 	})
 	if err != nil {
-		c.JSON(resp.StatusCode, &JSONResultError{
-			Code:  resp.StatusCode,
-			Error: fmt.Sprintf("Unable to set repositories for runner group %s: %v", team, err),
-		})
+		writeAPIError(c, gitHubError(resp, err), fmt.Sprintf("Unable to set repositories for runner group %s: %v", team, err))
 		return
 	}
 	m.Logger.WithField("uuid", uuid).WithField("team", team).Debug("Added repositories to runner group")
@@ -407,3 +369,94 @@ func findRepoID(name string, teamRepos []*github.Repository) (int64, error) {
 	}
 	return -1, fmt.Errorf("team does not have repo access")
 }
+
+// parseRepoNames splits the repos parameter into repository names, dropping blank and repeated names
+func parseRepoNames(repos string) []string {
+	var names []string
+	seen := map[string]bool{}
+	for _, name := range strings.Split(repos, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+	return names
+}
+
+// listGroupRepos lists the IDs of the repositories assigned to the runner group by name
+func (m *Manager) listGroupRepos(ctx context.Context, groupID int64) (map[string]int64, *github.Response, error) {
+	groupRepos := map[string]int64{}
+	opts := &github.ListOptions{PerPage: 100}
+	for {
+		repos, resp, err := m.ActionsClient.ListRepositoryAccessRunnerGroup(ctx, m.Config.Org, groupID, opts)
+		if err != nil {
+			return nil, resp, err
+		}
+		for _, repo := range repos.Repositories {
+			groupRepos[repo.GetName()] = repo.GetID()
+		}
+		if resp.NextPage == 0 {
+			return groupRepos, resp, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// missingRepoResult looks up a repository the runner group operation cannot act on, reporting it with the status when
+// it exists in the organization and as not found when it does not
+func (m *Manager) missingRepoResult(ctx context.Context, name, status string) *repoResult {
+	_, resp, err := m.RepositoriesClient.Get(ctx, m.Config.Org, name)
+	if err != nil {
+		if resp != nil && resp.Response != nil && resp.StatusCode == http.StatusNotFound {
+			return &repoResult{Repo: name, Status: RepoStatusNotFound}
+		}
+		return failedRepoResult(name, resp, err)
+	}
+	return &repoResult{Repo: name, Status: status}
+}
+
+// failedRepoResult reports a failed GitHub API call for a repository with the status and error code it maps to
+func failedRepoResult(name string, resp *github.Response, err error) *repoResult {
+	apiErr := gitHubError(resp, err)
+	return &repoResult{
+		Repo:      name,
+		Status:    RepoStatusFailed,
+		Code:      apiErr.status,
+		Error:     err.Error(),
+		ErrorCode: apiErr.code,
+		Retryable: apiErr.retryable,
+	}
+}
+
+// repoResultsCode returns the status of a call with the results, 207 when any repository failed and 200 otherwise
+func repoResultsCode(results []*repoResult) int {
+	for _, result := range results {
+		if result.failed() {
+			return http.StatusMultiStatus
+		}
+	}
+	return http.StatusOK
+}
+
+// reposWithStatus returns the repositories whose result has the status
+func reposWithStatus(results []*repoResult, status string) []string {
+	var repos []string
+	for _, result := range results {
+		if result.Status == status {
+			repos = append(repos, result.Repo)
+		}
+	}
+	return repos
+}
+
+// writeRepoResults responds with the result of every repository, with a 207 status when any of them failed so callers
+// can retry exactly the repositories that failed
+func writeRepoResults(c *gin.Context, results []*repoResult) {
+	code := repoResultsCode(results)
+	c.JSON(code, &JSONResultSuccess{
+		Code:     code,
+		Response: results,
+	})
+}
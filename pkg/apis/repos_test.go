@@ -0,0 +1,187 @@
+/**
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package apis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-github/v41/github"
+	"github.com/lindluni/actions-runner-manager/pkg/apis/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+// newReposManager creates a manager whose team has access to the present, new and unlisted repositories, and whose
+// runner group is assigned the present and broken repositories. The missing repository does not exist in the
+// organization.
+func newReposManager(actionsClient *mocks.ActionsClient) *Manager {
+	manager := newRunnerManager(actionsClient)
+	actionsClient.ListRepositoryAccessRunnerGroupReturns(&github.ListRepositories{
+		Repositories: []*github.Repository{
+			{ID: github.Int64(1), Name: github.String("present")},
+			{ID: github.Int64(5), Name: github.String("broken")},
+		},
+	}, &github.Response{}, nil)
+	teamsClient := &mocks.TeamsClient{}
+	teamsClient.ListTeamReposBySlugReturns([]*github.Repository{
+		{ID: github.Int64(1), Name: github.String("present")},
+		{ID: github.Int64(2), Name: github.String("new")},
+		{ID: github.Int64(6), Name: github.String("unlisted")},
+	}, &github.Response{}, nil)
+	repositoriesClient := &mocks.RepositoriesClient{}
+	repositoriesClient.GetCalls(func(_ context.Context, _, name string) (*github.Repository, *github.Response, error) {
+		if name == "missing" {
+			return nil, &github.Response{Response: &http.Response{StatusCode: http.StatusNotFound}}, errors.New("not found")
+		}
+		return &github.Repository{Name: github.String(name)}, &github.Response{}, nil
+	})
+	manager.TeamsClient = teamsClient
+	manager.RepositoriesClient = repositoriesClient
+	return manager
+}
+
+func TestDoReposAddRemove(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		path    string
+		code    int
+		results []*repoResult
+		calls   int
+	}{
+		{
+			name: "add",
+			path: "/api/v1/repos-add?team=fake-team&repos=new,present,new",
+			code: http.StatusOK,
+			results: []*repoResult{
+				{Repo: "new", Status: RepoStatusAdded},
+				{Repo: "present", Status: RepoStatusAlreadyPresent},
+			},
+			calls: 1,
+		},
+		{
+			name: "add partially",
+			path: "/api/v1/repos-add?team=fake-team&repos=new,other,missing,unlisted",
+			code: http.StatusMultiStatus,
+			results: []*repoResult{
+				{Repo: "new", Status: RepoStatusAdded},
+				{Repo: "other", Status: RepoStatusNotInTeam},
+				{Repo: "missing", Status: RepoStatusNotFound},
+				{Repo: "unlisted", Status: RepoStatusFailed, Code: http.StatusBadGateway, Error: "bad gateway", ErrorCode: ErrorCodeGitHubUnavailable, Retryable: true},
+			},
+			calls: 2,
+		},
+		{
+			name: "remove",
+			path: "/api/v1/repos-remove?team=fake-team&repos=present,other",
+			code: http.StatusOK,
+			results: []*repoResult{
+				{Repo: "present", Status: RepoStatusRemoved},
+				{Repo: "other", Status: RepoStatusNotPresent},
+			},
+			calls: 1,
+		},
+		{
+			name: "remove partially",
+			path: "/api/v1/repos-remove?team=fake-team&repos=present,missing,broken",
+			code: http.StatusMultiStatus,
+			results: []*repoResult{
+				{Repo: "present", Status: RepoStatusRemoved},
+				{Repo: "missing", Status: RepoStatusNotFound},
+				{Repo: "broken", Status: RepoStatusFailed, Code: http.StatusBadGateway, Error: "connection reset", ErrorCode: ErrorCodeGitHubUnavailable, Retryable: true},
+			},
+			calls: 2,
+		},
+	}
+
+	for _, tc := range tests {
+		actionsClient := &mocks.ActionsClient{}
+		manager := newReposManager(actionsClient)
+		actionsClient.AddRepositoryAccessRunnerGroupCalls(func(_ context.Context, _ string, _, repoID int64) (*github.Response, error) {
+			if repoID == 6 {
+				return &github.Response{Response: &http.Response{StatusCode: http.StatusBadGateway}}, errors.New("bad gateway")
+			}
+			return &github.Response{Response: &http.Response{StatusCode: http.StatusNoContent}}, nil
+		})
+		actionsClient.RemoveRepositoryAccessRunnerGroupCalls(func(_ context.Context, _ string, _, repoID int64) (*github.Response, error) {
+			if repoID == 5 {
+				return nil, errors.New("connection reset")
+			}
+			return &github.Response{Response: &http.Response{StatusCode: http.StatusNoContent}}, nil
+		})
+
+		writer := httptest.NewRecorder()
+		request, err := http.NewRequest(http.MethodPatch, tc.path, nil)
+		require.NoError(t, err, tc.name)
+		request.Header.Set("Authorization", "test-token")
+		manager.Router.ServeHTTP(writer, request)
+		require.Equal(t, tc.code, writer.Code, tc.name)
+
+		response := &struct {
+			Code     int
+			Response []*repoResult
+		}{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), response), tc.name)
+		require.Equal(t, tc.code, response.Code, tc.name)
+		require.Equal(t, tc.results, response.Response, tc.name)
+		require.Equal(t, tc.calls, actionsClient.AddRepositoryAccessRunnerGroupCallCount()+actionsClient.RemoveRepositoryAccessRunnerGroupCallCount(), tc.name)
+	}
+}
+
+func TestDoReposAdd_MissingRepos(t *testing.T) {
+	t.Parallel()
+
+	actionsClient := &mocks.ActionsClient{}
+	manager := newReposManager(actionsClient)
+
+	writer := httptest.NewRecorder()
+	request, err := http.NewRequest(http.MethodPatch, "/api/v1/repos-add?team=fake-team&repos=,", nil)
+	require.NoError(t, err)
+	request.Header.Set("Authorization", "test-token")
+	manager.Router.ServeHTTP(writer, request)
+	require.Equal(t, http.StatusBadRequest, writer.Code)
+	require.Equal(t, 0, actionsClient.ListOrganizationRunnerGroupsCallCount())
+}
+
+func TestDoReposSet(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		repos string
+		code  int
+		ids   []int64
+	}{
+		{name: "trimmed and deduplicated", repos: "present,%20new%20,present,,", code: http.StatusOK, ids: []int64{1, 2}},
+		{name: "blank", repos: ",%20,", code: http.StatusBadRequest},
+	}
+	for _, tc := range tests {
+		actionsClient := &mocks.ActionsClient{}
+		actionsClient.SetRepositoryAccessRunnerGroupReturns(&github.Response{}, nil)
+		manager := newReposManager(actionsClient)
+
+		writer := httptest.NewRecorder()
+		request, err := http.NewRequest(http.MethodPatch, "/api/v1/repos-set?team=fake-team&repos="+tc.repos, nil)
+		require.NoError(t, err, tc.name)
+		request.Header.Set("Authorization", "test-token")
+		manager.Router.ServeHTTP(writer, request)
+		require.Equal(t, tc.code, writer.Code, tc.name)
+
+		if tc.ids == nil {
+			require.Equal(t, 0, actionsClient.SetRepositoryAccessRunnerGroupCallCount(), tc.name)
+			continue
+		}
+		require.Equal(t, 1, actionsClient.SetRepositoryAccessRunnerGroupCallCount(), tc.name)
+		_, org, groupID, ids := actionsClient.SetRepositoryAccessRunnerGroupArgsForCall(0)
+		require.Equal(t, "fake-org", org, tc.name)
+		require.Equal(t, int64(2), groupID, tc.name)
+		require.Equal(t, tc.ids, ids.SelectedRepositoryIDs, tc.name)
+	}
+}
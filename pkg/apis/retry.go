@@ -0,0 +1,229 @@
+/**
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package apis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultMaxRetries = 3
+	defaultMinBackoff = time.Second
+	defaultMaxBackoff = 30 * time.Second
+	defaultMaxWait    = time.Minute
+
+	// RetryReasonRateLimit labels retries of calls rejected by a primary or secondary GitHub API rate limit
+	RetryReasonRateLimit = "rate_limit"
+	// RetryReasonServerError labels retries of idempotent calls that received a 5xx response
+	RetryReasonServerError = "server_error"
+	// RetryReasonNetworkError labels retries of idempotent calls that did not receive a response
+	RetryReasonNetworkError = "network_error"
+)
+
+// Retry configures how GitHub API calls that fail with a transient error or are rejected by a rate limit are retried.
+// Every setting has a default when unset, and a negative MaxRetries disables retries.
+type Retry struct {
+	MaxRetries int           `yaml:"maxRetries"`
+	MinBackoff time.Duration `yaml:"minBackoff"`
+	MaxBackoff time.Duration `yaml:"maxBackoff"`
+	MaxWait    time.Duration `yaml:"maxWait"`
+}
+
+// Validate verifies the backoff settings are not negative and the minimum backoff does not exceed the maximum
+func (r Retry) Validate() error {
+	if r.MinBackoff < 0 || r.MaxBackoff < 0 || r.MaxWait < 0 {
+		return fmt.Errorf("minBackoff, maxBackoff and maxWait must not be negative")
+	}
+	if r.minBackoff() > r.maxBackoff() {
+		return fmt.Errorf("minBackoff %s must not exceed maxBackoff %s", r.minBackoff(), r.maxBackoff())
+	}
+	return nil
+}
+
+func (r Retry) maxRetries() int {
+	if r.MaxRetries == 0 {
+		return defaultMaxRetries
+	}
+	if r.MaxRetries < 0 {
+		return 0
+	}
+	return r.MaxRetries
+}
+
+func (r Retry) minBackoff() time.Duration {
+	if r.MinBackoff == 0 {
+		return defaultMinBackoff
+	}
+	return r.MinBackoff
+}
+
+func (r Retry) maxBackoff() time.Duration {
+	if r.MaxBackoff == 0 {
+		return defaultMaxBackoff
+	}
+	return r.MaxBackoff
+}
+
+func (r Retry) maxWait() time.Duration {
+	if r.MaxWait == 0 {
+		return defaultMaxWait
+	}
+	return r.MaxWait
+}
+
+// RetryTransport is an http.RoundTripper that retries GitHub API calls. Calls rejected by a rate limit are retried
+// whatever their method, as GitHub did not act on them, after the wait given by the Retry-After or X-RateLimit-Reset
+// header when it does not exceed MaxWait. Idempotent calls that receive a 5xx response or no response at all are
+// retried with jittered exponential backoff. Once the retries are exhausted the last response or error is returned.
+type RetryTransport struct {
+	base    http.RoundTripper
+	config  Retry
+	metrics *Metrics
+	client  string
+	now     func() time.Time
+	jitter  func(time.Duration) time.Duration
+}
+
+// NewRetryTransport creates a transport that retries the calls it sends through base, counting the retries of the
+// client in metrics
+func NewRetryTransport(base http.RoundTripper, config Retry, metrics *Metrics, client string) *RetryTransport {
+	return &RetryTransport{
+		base:    base,
+		config:  config,
+		metrics: metrics,
+		client:  client,
+		now:     time.Now,
+		jitter: func(d time.Duration) time.Duration {
+			// Equal jitter keeps at least half of the backoff while spreading retries of concurrent calls
+			return d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+		},
+	}
+}
+
+// RoundTrip sends the request, retrying it as configured
+func (t *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		attemptReq, err := t.rewind(req, attempt)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := t.base.RoundTrip(attemptReq)
+		if attempt >= t.config.maxRetries() || req.Body != nil && req.GetBody == nil {
+			return resp, err
+		}
+
+		wait, reason, retry := t.retryAfter(req, resp, err, attempt)
+		if !retry {
+			return resp, err
+		}
+		if resp != nil {
+			// Drain the body so the connection can be reused by the next attempt
+			_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+			resp.Body.Close()
+		}
+		t.metrics.RetriedGitHubCall(t.client, reason)
+		if err := sleep(req.Context(), wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// rewind returns the request to send for the attempt, with a fresh body for every attempt after the first
+func (t *RetryTransport) rewind(req *http.Request, attempt int) (*http.Request, error) {
+	if attempt == 0 || req.Body == nil {
+		return req, nil
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, fmt.Errorf("unable to rewind request body: %w", err)
+	}
+	attemptReq := req.Clone(req.Context())
+	attemptReq.Body = body
+	return attemptReq, nil
+}
+
+// retryAfter reports whether the attempt should be retried, why, and how long to wait before retrying it
+func (t *RetryTransport) retryAfter(req *http.Request, resp *http.Response, err error, attempt int) (time.Duration, string, bool) {
+	if err != nil {
+		if !isIdempotent(req.Method) || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			return 0, "", false
+		}
+		return t.backoff(attempt), RetryReasonNetworkError, true
+	}
+	if wait, limited := t.rateLimitWait(resp); limited {
+		if wait > t.config.maxWait() {
+			return 0, "", false
+		}
+		if wait < t.config.minBackoff() {
+			wait = t.config.minBackoff()
+		}
+		return wait, RetryReasonRateLimit, true
+	}
+	switch resp.StatusCode {
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		if isIdempotent(req.Method) {
+			return t.backoff(attempt), RetryReasonServerError, true
+		}
+	}
+	return 0, "", false
+}
+
+// rateLimitWait reports whether the response was rejected by a rate limit, and how long GitHub asks to wait before
+// retrying. Secondary rate limits set Retry-After, while exhausted primary rate limits report when they reset.
+func (t *RetryTransport) rateLimitWait(resp *http.Response) (time.Duration, bool) {
+	if resp.StatusCode != http.StatusForbidden && resp.StatusCode != http.StatusTooManyRequests {
+		return 0, false
+	}
+	if seconds, err := strconv.ParseInt(resp.Header.Get("Retry-After"), 10, 64); err == nil {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if resp.Header.Get("X-RateLimit-Remaining") == "0" {
+		if reset, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+			return time.Unix(reset, 0).Sub(t.now()), true
+		}
+	}
+	// A 403 without rate limit headers is a permission error, while a 429 always is a rate limit
+	return 0, resp.StatusCode == http.StatusTooManyRequests
+}
+
+// backoff returns the jittered exponential backoff before the retry of the attempt
+func (t *RetryTransport) backoff(attempt int) time.Duration {
+	backoff := t.config.minBackoff()
+	for i := 0; i < attempt && backoff < t.config.maxBackoff(); i++ {
+		backoff *= 2
+	}
+	if backoff > t.config.maxBackoff() {
+		backoff = t.config.maxBackoff()
+	}
+	return t.jitter(backoff)
+}
+
+// isIdempotent reports whether sending a request with the method more than once has the same effect as sending it once
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// sleep waits for the duration unless the context is done first
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
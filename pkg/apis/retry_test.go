@@ -0,0 +1,209 @@
+/**
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package apis
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v41/github"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeGitHub serves the responses in order, repeating the last one, and counts the requests it receives
+type fakeGitHub struct {
+	responses []func(http.ResponseWriter)
+	requests  int32
+}
+
+func (f *fakeGitHub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	i := int(atomic.AddInt32(&f.requests, 1)) - 1
+	if i >= len(f.responses) {
+		i = len(f.responses) - 1
+	}
+	f.responses[i](w)
+}
+
+func respond(code int, headers map[string]string, body string) func(http.ResponseWriter) {
+	return func(w http.ResponseWriter) {
+		for key, value := range headers {
+			w.Header().Set(key, value)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(code)
+		_, _ = w.Write([]byte(body))
+	}
+}
+
+func TestRetryTransport(t *testing.T) {
+	t.Parallel()
+
+	runners := `{"total_count": 0, "runners": []}`
+	token := `{"token": "fake-token"}`
+	tests := []struct {
+		name      string
+		config    Retry
+		post      bool
+		responses []func(http.ResponseWriter)
+		requests  int32
+		code      int
+		retries   map[string]float64
+	}{
+		{
+			name: "server error",
+			responses: []func(http.ResponseWriter){
+				respond(http.StatusServiceUnavailable, nil, `{}`),
+				respond(http.StatusBadGateway, nil, `{}`),
+				respond(http.StatusOK, nil, runners),
+			},
+			requests: 3,
+			code:     http.StatusOK,
+			retries:  map[string]float64{RetryReasonServerError: 2},
+		},
+		{
+			name:   "server error exhausts retries",
+			config: Retry{MaxRetries: 2},
+			responses: []func(http.ResponseWriter){
+				respond(http.StatusInternalServerError, nil, `{}`),
+			},
+			requests: 3,
+			code:     http.StatusInternalServerError,
+			retries:  map[string]float64{RetryReasonServerError: 2},
+		},
+		{
+			name:   "retries disabled",
+			config: Retry{MaxRetries: -1},
+			responses: []func(http.ResponseWriter){
+				respond(http.StatusInternalServerError, nil, `{}`),
+			},
+			requests: 1,
+			code:     http.StatusInternalServerError,
+		},
+		{
+			name: "server error of non-idempotent call",
+			post: true,
+			responses: []func(http.ResponseWriter){
+				respond(http.StatusBadGateway, nil, `{}`),
+			},
+			requests: 1,
+			code:     http.StatusBadGateway,
+		},
+		{
+			name: "secondary rate limit of non-idempotent call",
+			post: true,
+			responses: []func(http.ResponseWriter){
+				respond(http.StatusForbidden, map[string]string{"Retry-After": "0"}, `{"message": "You have exceeded a secondary rate limit"}`),
+				respond(http.StatusCreated, nil, token),
+			},
+			requests: 2,
+			code:     http.StatusCreated,
+			retries:  map[string]float64{RetryReasonRateLimit: 1},
+		},
+		{
+			name: "primary rate limit reset",
+			responses: []func(http.ResponseWriter){
+				respond(http.StatusForbidden, map[string]string{
+					"X-RateLimit-Remaining": "0",
+					"X-RateLimit-Reset":     strconv.FormatInt(time.Now().Unix(), 10),
+				}, `{"message": "API rate limit exceeded"}`),
+				respond(http.StatusOK, nil, runners),
+			},
+			requests: 2,
+			code:     http.StatusOK,
+			retries:  map[string]float64{RetryReasonRateLimit: 1},
+		},
+		{
+			name: "rate limit beyond max wait",
+			responses: []func(http.ResponseWriter){
+				respond(http.StatusForbidden, map[string]string{
+					"X-RateLimit-Remaining": "0",
+					"X-RateLimit-Reset":     strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10),
+				}, `{"message": "API rate limit exceeded"}`),
+			},
+			requests: 1,
+			code:     http.StatusTooManyRequests,
+		},
+		{
+			name: "permission error",
+			responses: []func(http.ResponseWriter){
+				respond(http.StatusForbidden, nil, `{"message": "Resource not accessible by integration"}`),
+			},
+			requests: 1,
+			code:     http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range tests {
+		fake := &fakeGitHub{responses: tc.responses}
+		server := httptest.NewServer(fake)
+
+		config := tc.config
+		config.MinBackoff = time.Millisecond
+		config.MaxBackoff = 5 * time.Millisecond
+		metrics := NewMetrics()
+		transport := NewRetryTransport(http.DefaultTransport, config, metrics, "installation")
+		client, err := GitHub{BaseURL: server.URL}.NewClient(&http.Client{Transport: transport})
+		require.NoError(t, err, tc.name)
+
+		var resp *github.Response
+		if tc.post {
+			_, resp, err = client.Actions.CreateOrganizationRegistrationToken(context.Background(), "fake-org")
+		} else {
+			_, resp, err = client.Actions.ListRunnerGroupRunners(context.Background(), "fake-org", 2, nil)
+		}
+		server.Close()
+
+		require.Equal(t, tc.requests, atomic.LoadInt32(&fake.requests), tc.name)
+		if tc.code < http.StatusBadRequest {
+			require.NoError(t, err, tc.name)
+		} else {
+			require.Error(t, err, tc.name)
+		}
+		require.Equal(t, tc.code, gitHubCode(resp, err), tc.name)
+		for _, reason := range []string{RetryReasonRateLimit, RetryReasonServerError, RetryReasonNetworkError} {
+			require.Equal(t, tc.retries[reason], testutil.ToFloat64(metrics.githubRetries.WithLabelValues("installation", reason)), tc.name)
+		}
+	}
+}
+
+// gitHubCode returns the status of a successful call, or of the response a failed call results in
+func gitHubCode(resp *github.Response, err error) int {
+	if err != nil {
+		return gitHubError(resp, err).status
+	}
+	return resp.StatusCode
+}
+
+func TestRetryTransport_NetworkError(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+
+	metrics := NewMetrics()
+	transport := NewRetryTransport(http.DefaultTransport, Retry{MaxRetries: 2, MinBackoff: time.Millisecond}, metrics, "user")
+	client, err := GitHub{BaseURL: server.URL}.NewClient(&http.Client{Transport: transport})
+	require.NoError(t, err)
+
+	_, resp, err := client.Users.Get(context.Background(), "")
+	require.Error(t, err)
+	require.Equal(t, ErrorCodeGitHubUnavailable, gitHubError(resp, err).code)
+	require.Equal(t, float64(2), testutil.ToFloat64(metrics.githubRetries.WithLabelValues("user", RetryReasonNetworkError)))
+}
+
+func TestRetry_Validate(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, Retry{}.Validate())
+	require.NoError(t, Retry{MaxRetries: -1, MinBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}.Validate())
+	require.EqualError(t, Retry{MinBackoff: -time.Second}.Validate(), "minBackoff, maxBackoff and maxWait must not be negative")
+	require.EqualError(t, Retry{MinBackoff: time.Minute}.Validate(), "minBackoff 1m0s must not exceed maxBackoff 30s")
+}
@@ -0,0 +1,309 @@
+/**
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package apis
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-contrib/requestid"
+	"github.com/gin-gonic/gin"
+	"github.com/google/go-github/v41/github"
+)
+
+// selfHostedLabel is applied to every self-hosted runner by GitHub, and must be part of a just-in-time configuration
+const selfHostedLabel = "self-hosted"
+
+// JITRunnerConfigRequest is the request body of the generate-jitconfig API
+type JITRunnerConfigRequest struct {
+	Name          string   `json:"name"`
+	RunnerGroupID int64    `json:"runner_group_id"`
+	Labels        []string `json:"labels"`
+	WorkFolder    string   `json:"work_folder,omitempty"`
+}
+
+// JITRunnerConfig is a just-in-time runner configuration that registers a single runner into a fixed runner group
+type JITRunnerConfig struct {
+	Runner           *github.Runner `json:"runner"`
+	EncodedJITConfig string         `json:"encoded_jit_config"`
+}
+
+// generateOrgJITConfig generates a just-in-time configuration for a runner in the organization. The API is not yet
+// exposed by the go-github ActionsService, so the request is sent through the underlying REST client.
+func (m *Manager) generateOrgJITConfig(ctx context.Context, request *JITRunnerConfigRequest) (*JITRunnerConfig, *github.Response, error) {
+	u := fmt.Sprintf("orgs/%v/actions/runners/generate-jitconfig", m.Config.Org)
+	req, err := m.RestClient.NewRequest(http.MethodPost, u, request)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	config := &JITRunnerConfig{}
+	resp, err := m.RestClient.Do(ctx, req, config)
+	if err != nil {
+		return nil, resp, err
+	}
+	return config, resp, nil
+}
+
+// DoRunnerRegister Register a GitHub Actions runner into the runner group of the team
+// @Summary      Register a GitHub Actions runner into the runner group of the team
+// @Description  Generates a just-in-time runner configuration bound to the runner group named with the team slug, so the runner can only register into the group of the team. Start the runner with `./run.sh --jitconfig <encodedJITConfig>`.
+// @Tags         Runners
+// @Produce      json
+// @Param        team    query     string    true   "Canonical **slug** of the GitHub team"
+// @Param        group   query     string    false  "Suffix of the runner group of the team, selects its default runner group when unset"
+// @Param        name    query     string    true   "Name of the runner"
+// @Param        labels  query     []string  false  "Comma-seperated list of additional runner labels"
+// @Param        work    query     string    false  "Working directory of the runner, defaults to _work"
+// @Success      200     {object}  JSONResultSuccess{Code=int,Response=JITRunnerConfig}
+// @Router       /v1/runner-register [post]
+// @Security     ApiKeyAuth
+func (m *Manager) DoRunnerRegister(c *gin.Context) {
+	uuid := requestid.Get(c)
+	team := c.GetString(teamKey)
+
+	m.Logger.WithField("uuid", uuid).WithField("team", team).Info("Retrieving runner parameters")
+	suffix, ok := m.retrieveGroupSuffix(c)
+	if !ok {
+		return
+	}
+	name := c.Query("name")
+	if name == "" {
+		writeError(c, http.StatusBadRequest, ErrorCodeBadRequest, "Missing required parameter: name")
+		return
+	}
+	c.Set(runnerKey, name)
+	// Unlike the labels-* APIs, the operating system and architecture labels may be set when registering a runner
+	labels := []string{selfHostedLabel}
+	for _, label := range parseLabels(c.Query("labels")) {
+		if !strings.EqualFold(label, selfHostedLabel) {
+			labels = append(labels, label)
+		}
+	}
+	m.Logger.WithField("uuid", uuid).WithField("team", team).Debug("Retrieved runner parameters")
+
+	m.Logger.WithField("uuid", uuid).WithField("team", team).Info("Retrieving runner group ID")
+	groupID, err := m.retrieveGroupID(team, suffix, uuid)
+	if err != nil {
+		writeAPIError(c, err, fmt.Sprintf("Unable to retrieve group ID: %v", err))
+		return
+	}
+	m.Logger.WithField("uuid", uuid).WithField("team", team).Debug("Retrieved runner group ID")
+
+	ctx := context.Background()
+	m.Logger.WithField("uuid", uuid).WithField("team", team).Infof("Generating just-in-time configuration for runner %s", name)
+	config, resp, err := m.generateOrgJITConfig(ctx, &JITRunnerConfigRequest{
+		Name:          name,
+		RunnerGroupID: *groupID,
+		Labels:        labels,
+		WorkFolder:    c.DefaultQuery("work", "_work"),
+	})
+	if err != nil {
+		apiErr := gitHubError(resp, err)
+		if apiErr.status == http.StatusConflict {
+			writeAPIError(c, apiErr, fmt.Sprintf("Runner already exists: %s", name))
+			return
+		}
+		writeAPIError(c, apiErr, fmt.Sprintf("Unable to generate just-in-time runner configuration: %v", err))
+		return
+	}
+	m.Logger.WithField("uuid", uuid).WithField("team", team).Debugf("Generated just-in-time configuration for runner %s", name)
+	m.Metrics.TokenIssued(team, TokenTypeJIT)
+
+	c.JSON(http.StatusOK, &JSONResultSuccess{
+		Code:     http.StatusOK,
+		Response: config,
+	})
+}
+
+type runnerLabel struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+type runnerDetails struct {
+	ID     int64          `json:"id"`
+	Name   string         `json:"name"`
+	OS     string         `json:"os"`
+	Status string         `json:"status"`
+	Busy   bool           `json:"busy"`
+	Labels []*runnerLabel `json:"labels"`
+}
+
+// newRunnerDetails flattens a runner returned by the GitHub API into its API response
+func newRunnerDetails(runner *github.Runner) *runnerDetails {
+	details := &runnerDetails{
+		ID:     runner.GetID(),
+		Name:   runner.GetName(),
+		OS:     runner.GetOS(),
+		Status: runner.GetStatus(),
+		Busy:   runner.GetBusy(),
+		Labels: []*runnerLabel{},
+	}
+	for _, label := range runner.Labels {
+		details.Labels = append(details.Labels, &runnerLabel{
+			ID:   label.GetID(),
+			Name: label.GetName(),
+			Type: label.GetType(),
+		})
+	}
+	return details
+}
+
+// listGroupRunners lists every runner registered to the runner group
+func (m *Manager) listGroupRunners(ctx context.Context, groupID int64) ([]*github.Runner, *github.Response, error) {
+	var runners []*github.Runner
+	opts := &github.ListOptions{PerPage: 100}
+	for {
+		runnerGroupRunners, resp, err := m.ActionsClient.ListRunnerGroupRunners(ctx, m.Config.Org, groupID, opts)
+		if err != nil {
+			return nil, resp, err
+		}
+		runners = append(runners, runnerGroupRunners.Runners...)
+		if resp.NextPage == 0 {
+			return runners, resp, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// findGroupRunner finds the runner with the name or ID in the runner group of the team, writing the error response and
+// returning nil if the runner cannot be found
+func (m *Manager) findGroupRunner(c *gin.Context, team, suffix, runner, uuid string) *github.Runner {
+	m.Logger.WithField("uuid", uuid).WithField("team", team).Info("Retrieving runner group ID")
+	groupID, err := m.retrieveGroupID(team, suffix, uuid)
+	if err != nil {
+		writeAPIError(c, err, fmt.Sprintf("Unable to retrieve group ID: %v", err))
+		return nil
+	}
+	m.Logger.WithField("uuid", uuid).WithField("team", team).Debug("Retrieved runner group ID")
+
+	m.Logger.WithField("uuid", uuid).WithField("team", team).Infof("Searching runner group for runner %s", runner)
+	runners, resp, err := m.listGroupRunners(context.Background(), *groupID)
+	if err != nil {
+		writeAPIError(c, gitHubError(resp, err), fmt.Sprintf("Unable to list runners: %v", err))
+		return nil
+	}
+	for _, groupRunner := range runners {
+		if groupRunner.GetName() == runner || strconv.FormatInt(groupRunner.GetID(), 10) == runner {
+			m.Logger.WithField("uuid", uuid).WithField("team", team).Debugf("Found runner %s", runner)
+			c.Set(runnerKey, groupRunner.GetName())
+			return groupRunner
+		}
+	}
+
+	writeError(c, http.StatusNotFound, ErrorCodeNotFound, fmt.Sprintf("Unable to locate runner %s in the runner group of the team", runner))
+	return nil
+}
+
+// DoRunnerList  List the runners of a GitHub Actions organization runner group
+// @Summary      List the runners of a GitHub Actions organization runner group
+// @Description  Lists the ID, operating system, status, busy flag and labels of every runner in the runner group named with the team slug
+// @Tags         Runners
+// @Produce      json
+// @Param        team   query     string  true   "Canonical **slug** of the GitHub team"
+// @Param        group  query     string  false  "Suffix of the runner group of the team, selects its default runner group when unset"
+// @Success      200    {object}  JSONResultSuccess{Code=int,Response=[]runnerDetails}
+// @Router       /v1/runner-list [get]
+// @Security     ApiKeyAuth
+func (m *Manager) DoRunnerList(c *gin.Context) {
+	uuid := requestid.Get(c)
+	team := c.GetString(teamKey)
+
+	m.Logger.WithField("uuid", uuid).WithField("team", team).Info("Retrieving group parameter")
+	suffix, ok := m.retrieveGroupSuffix(c)
+	if !ok {
+		return
+	}
+	m.Logger.WithField("uuid", uuid).WithField("team", team).Debug("Retrieved group parameter")
+
+	m.Logger.WithField("uuid", uuid).WithField("team", team).Info("Retrieving runner group ID")
+	groupID, err := m.retrieveGroupID(team, suffix, uuid)
+	if err != nil {
+		writeAPIError(c, err, fmt.Sprintf("Unable to retrieve group ID: %v", err))
+		return
+	}
+	m.Logger.WithField("uuid", uuid).WithField("team", team).Debug("Retrieved runner group ID")
+
+	m.Logger.WithField("uuid", uuid).WithField("team", team).Info("Retrieving runner group runner list")
+	runners, resp, err := m.listGroupRunners(context.Background(), *groupID)
+	if err != nil {
+		writeAPIError(c, gitHubError(resp, err), fmt.Sprintf("Unable to list runners: %v", err))
+		return
+	}
+	details := []*runnerDetails{}
+	for _, runner := range runners {
+		details = append(details, newRunnerDetails(runner))
+	}
+	m.Logger.WithField("uuid", uuid).WithField("team", team).Debug("Retrieved runner group runner list")
+
+	c.JSON(http.StatusOK, &JSONResultSuccess{
+		Code:     http.StatusOK,
+		Response: details,
+	})
+}
+
+// DoRunnerDelete Delete a runner from a GitHub Actions organization runner group
+// @Summary      Delete a runner from a GitHub Actions organization runner group
+// @Description  Removes a runner, identified by name or ID, from the organization after confirming it belongs to the runner group named with the team slug. Busy runners are not removed.
+// @Tags         Runners
+// @Produce      json
+// @Param        team    query     string  true   "Canonical **slug** of the GitHub team"
+// @Param        group   query     string  false  "Suffix of the runner group of the team, selects its default runner group when unset"
+// @Param        runner  query     string  true   "Name or ID of the runner"
+// @Success      200     {object}  JSONResultSuccess{Code=int,Response=string}
+// @Router       /v1/runner-delete [delete]
+// @Security     ApiKeyAuth
+func (m *Manager) DoRunnerDelete(c *gin.Context) {
+	uuid := requestid.Get(c)
+	team := c.GetString(teamKey)
+
+	m.Logger.WithField("uuid", uuid).WithField("team", team).Info("Retrieving group and runner parameters")
+	suffix, ok := m.retrieveGroupSuffix(c)
+	if !ok {
+		return
+	}
+	name := c.Query("runner")
+	if name == "" {
+		writeError(c, http.StatusBadRequest, ErrorCodeBadRequest, "Missing required parameter: runner")
+		return
+	}
+	m.Logger.WithField("uuid", uuid).WithField("team", team).Debug("Retrieved group and runner parameters")
+
+	groupRunner := m.findGroupRunner(c, team, suffix, name, uuid)
+	if groupRunner == nil {
+		return
+	}
+
+	ctx := context.Background()
+	m.Logger.WithField("uuid", uuid).WithField("team", team).Infof("Retrieving current status of runner %s", name)
+	runner, resp, err := m.ActionsClient.GetOrganizationRunner(ctx, m.Config.Org, groupRunner.GetID())
+	if err != nil {
+		writeAPIError(c, gitHubError(resp, err), fmt.Sprintf("Unable to retrieve runner: %v", err))
+		return
+	}
+	if runner.GetBusy() {
+		writeError(c, http.StatusConflict, ErrorCodeConflict, fmt.Sprintf("Runner %s is running a job and cannot be removed", runner.GetName()))
+		return
+	}
+	m.Logger.WithField("uuid", uuid).WithField("team", team).Debugf("Retrieved current status of runner %s", name)
+
+	m.Logger.WithField("uuid", uuid).WithField("team", team).Infof("Removing runner %s", name)
+	resp, err = m.ActionsClient.RemoveOrganizationRunner(ctx, m.Config.Org, runner.GetID())
+	if err != nil {
+		writeAPIError(c, gitHubError(resp, err), fmt.Sprintf("Unable to remove runner: %v", err))
+		return
+	}
+	m.Logger.WithField("uuid", uuid).WithField("team", team).Debugf("Removed runner %s", name)
+
+	c.JSON(http.StatusOK, &JSONResultSuccess{
+		Code:     http.StatusOK,
+		Response: fmt.Sprintf("Runner removed successfully: %s", runner.GetName()),
+	})
+}
@@ -0,0 +1,332 @@
+/**
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package apis
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/didip/tollbooth/v6"
+	"github.com/gin-gonic/gin"
+	"github.com/google/go-github/v41/github"
+	"github.com/lindluni/actions-runner-manager/pkg/apis/mocks"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDoRunnerRegister(t *testing.T) {
+	t.Parallel()
+
+	actionsClient := &mocks.ActionsClient{}
+	actionsClient.ListOrganizationRunnerGroupsReturns(&github.RunnerGroups{
+		RunnerGroups: []*github.RunnerGroup{{ID: github.Int64(2), Name: github.String("fake-team")}},
+	}, &github.Response{}, nil)
+	restClient := &mocks.RestClient{}
+	restClient.NewRequestStub = func(method, urlStr string, body interface{}) (*http.Request, error) {
+		return github.NewClient(nil).NewRequest(method, urlStr, body)
+	}
+	restClient.DoStub = func(_ context.Context, _ *http.Request, v interface{}) (*github.Response, error) {
+		config := v.(*JITRunnerConfig)
+		config.Runner = &github.Runner{ID: github.Int64(3), Name: github.String("fake-runner")}
+		config.EncodedJITConfig = "fake-config"
+		return &github.Response{Response: &http.Response{StatusCode: http.StatusCreated}}, nil
+	}
+	teamsClient := &mocks.TeamsClient{}
+	teamsClient.GetTeamMembershipBySlugReturns(&github.Membership{Role: github.String("maintainer")}, nil, nil)
+	logger, _ := test.NewNullLogger()
+	manager := &Manager{
+		ActionsClient: actionsClient,
+		RestClient:    restClient,
+		Config:        &Config{Org: "fake-org"},
+		CreateMaintainershipClient: func(string, string) (*MaintainershipClient, *github.User, error) {
+			return &MaintainershipClient{
+				TeamsClient: teamsClient,
+			}, &github.User{Login: github.String("fake-user")}, nil
+		},
+		Limit:  tollbooth.NewLimiter(1, nil),
+		Logger: logger,
+		Router: gin.New(),
+	}
+	manager.SetRoutes()
+
+	writer := httptest.NewRecorder()
+	request, err := http.NewRequest(http.MethodPost, "/api/v1/runner-register?team=fake-team&name=fake-runner&labels=gpu,%20gpu%20,,linux,self-hosted", nil)
+	require.NoError(t, err)
+	request.Header.Set("Authorization", "test-token")
+	manager.Router.ServeHTTP(writer, request)
+
+	result := writer.Result()
+	body, err := ioutil.ReadAll(result.Body)
+	defer result.Body.Close()
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, result.StatusCode)
+	require.JSONEq(t, `{"Code":200,"Response":{"runner":{"id":3,"name":"fake-runner"},"encoded_jit_config":"fake-config"}}`, string(body))
+
+	require.Equal(t, 1, restClient.NewRequestCallCount())
+	method, url, jitRequest := restClient.NewRequestArgsForCall(0)
+	require.Equal(t, http.MethodPost, method)
+	require.Equal(t, "orgs/fake-org/actions/runners/generate-jitconfig", url)
+	require.Equal(t, &JITRunnerConfigRequest{
+		Name:          "fake-runner",
+		RunnerGroupID: 2,
+		Labels:        []string{"self-hosted", "gpu", "linux"},
+		WorkFolder:    "_work",
+	}, jitRequest)
+}
+
+func TestDoRunnerRegister_Failure(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		url      string
+		groups   *github.RunnerGroups
+		respCode int
+		expected *JSONResultError
+	}{
+		{
+			name: "missing name",
+			url:  "/api/v1/runner-register?team=fake-team",
+			expected: &JSONResultError{
+				Code:      http.StatusBadRequest,
+				Error:     "Missing required parameter: name",
+				ErrorCode: ErrorCodeBadRequest,
+			},
+		},
+		{
+			name:   "missing group",
+			url:    "/api/v1/runner-register?team=fake-team&name=fake-runner",
+			groups: &github.RunnerGroups{},
+			expected: &JSONResultError{
+				Code:      http.StatusNotFound,
+				Error:     "Unable to retrieve group ID: unable to locate runner group with name fake-team",
+				ErrorCode: ErrorCodeGroupNotFound,
+			},
+		},
+		{
+			name: "runner exists",
+			url:  "/api/v1/runner-register?team=fake-team&name=fake-runner",
+			groups: &github.RunnerGroups{
+				RunnerGroups: []*github.RunnerGroup{{ID: github.Int64(2), Name: github.String("fake-team")}},
+			},
+			respCode: http.StatusConflict,
+			expected: &JSONResultError{
+				Code:      http.StatusConflict,
+				Error:     "Runner already exists: fake-runner",
+				ErrorCode: ErrorCodeConflict,
+			},
+		},
+	}
+
+	logger, _ := test.NewNullLogger()
+	for _, tc := range tests {
+		actionsClient := &mocks.ActionsClient{}
+		actionsClient.ListOrganizationRunnerGroupsReturns(tc.groups, &github.Response{}, nil)
+		restClient := &mocks.RestClient{}
+		restClient.DoReturns(&github.Response{Response: &http.Response{StatusCode: tc.respCode}}, &github.ErrorResponse{})
+		teamsClient := &mocks.TeamsClient{}
+		teamsClient.GetTeamMembershipBySlugReturns(&github.Membership{Role: github.String("maintainer")}, nil, nil)
+		manager := &Manager{
+			ActionsClient: actionsClient,
+			RestClient:    restClient,
+			Config:        &Config{Org: "fake-org"},
+			CreateMaintainershipClient: func(string, string) (*MaintainershipClient, *github.User, error) {
+				return &MaintainershipClient{
+					TeamsClient: teamsClient,
+				}, &github.User{Login: github.String("fake-user")}, nil
+			},
+			Limit:  tollbooth.NewLimiter(1, nil),
+			Logger: logger,
+			Router: gin.New(),
+		}
+		manager.SetRoutes()
+
+		writer := httptest.NewRecorder()
+		request, err := http.NewRequest(http.MethodPost, tc.url, nil)
+		require.NoError(t, err, tc.name)
+		request.Header.Set("Authorization", "test-token")
+		manager.Router.ServeHTTP(writer, request)
+
+		response := &JSONResultError{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), response), tc.name)
+		require.Equal(t, tc.expected, response, tc.name)
+		require.Equal(t, tc.expected.Code, writer.Code, tc.name)
+	}
+}
+
+func newRunnerManager(actionsClient *mocks.ActionsClient) *Manager {
+	actionsClient.ListOrganizationRunnerGroupsReturns(&github.RunnerGroups{
+		RunnerGroups: []*github.RunnerGroup{{ID: github.Int64(2), Name: github.String("fake-team")}},
+	}, &github.Response{}, nil)
+	actionsClient.ListRunnerGroupRunnersReturns(&github.Runners{
+		Runners: []*github.Runner{
+			{
+				ID:     github.Int64(3),
+				Name:   github.String("fake-runner"),
+				OS:     github.String("linux"),
+				Status: github.String("offline"),
+				Busy:   github.Bool(false),
+				Labels: []*github.RunnerLabels{{ID: github.Int64(1), Name: github.String("self-hosted"), Type: github.String("read-only")}},
+			},
+			{ID: github.Int64(4), Name: github.String("busy-runner"), Status: github.String("online"), Busy: github.Bool(true)},
+		},
+	}, &github.Response{}, nil)
+	teamsClient := &mocks.TeamsClient{}
+	teamsClient.GetTeamMembershipBySlugReturns(&github.Membership{Role: github.String("maintainer")}, nil, nil)
+	logger, _ := test.NewNullLogger()
+	manager := &Manager{
+		ActionsClient: actionsClient,
+		Config:        &Config{Org: "fake-org"},
+		CreateMaintainershipClient: func(string, string) (*MaintainershipClient, *github.User, error) {
+			return &MaintainershipClient{
+				TeamsClient: teamsClient,
+			}, &github.User{Login: github.String("fake-user")}, nil
+		},
+		Limit:  tollbooth.NewLimiter(1, nil),
+		Logger: logger,
+		Router: gin.New(),
+	}
+	manager.SetRoutes()
+	return manager
+}
+
+func TestBrokeredRegistration(t *testing.T) {
+	t.Parallel()
+
+	actionsClient := &mocks.ActionsClient{}
+	manager := newRunnerManager(actionsClient)
+	manager.Config.Policy.BrokeredRegistration = true
+	manager.Router = gin.New()
+	manager.SetRoutes()
+
+	for _, path := range []string{"/api/v1/token-register", "/api/v1/token-remove", "/api/v1/orgs/fake-org/token-register"} {
+		writer := httptest.NewRecorder()
+		request, err := http.NewRequest(http.MethodGet, path+"?team=fake-team", nil)
+		require.NoError(t, err, path)
+		request.Header.Set("Authorization", "test-token")
+		manager.Router.ServeHTTP(writer, request)
+		require.Equal(t, http.StatusNotFound, writer.Code, path)
+	}
+	require.Equal(t, 0, actionsClient.CreateOrganizationRegistrationTokenCallCount())
+	require.Equal(t, 0, actionsClient.CreateOrganizationRemoveTokenCallCount())
+}
+
+func TestDoRunnerList(t *testing.T) {
+	t.Parallel()
+
+	actionsClient := &mocks.ActionsClient{}
+	manager := newRunnerManager(actionsClient)
+
+	writer := httptest.NewRecorder()
+	request, err := http.NewRequest(http.MethodGet, "/api/v1/runner-list?team=fake-team", nil)
+	require.NoError(t, err)
+	request.Header.Set("Authorization", "test-token")
+	manager.Router.ServeHTTP(writer, request)
+
+	require.Equal(t, http.StatusOK, writer.Code)
+	require.JSONEq(t, `{"Code":200,"Response":[
+		{"id":3,"name":"fake-runner","os":"linux","status":"offline","busy":false,"labels":[{"id":1,"name":"self-hosted","type":"read-only"}]},
+		{"id":4,"name":"busy-runner","os":"","status":"online","busy":true,"labels":[]}
+	]}`, writer.Body.String())
+	_, org, groupID, _ := actionsClient.ListRunnerGroupRunnersArgsForCall(0)
+	require.Equal(t, "fake-org", org)
+	require.Equal(t, int64(2), groupID)
+}
+
+func TestDoRunnerDelete(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		runner   string
+		busy     bool
+		expected *JSONResultError
+		removed  bool
+	}{
+		{
+			name:     "by name",
+			runner:   "fake-runner",
+			expected: &JSONResultError{Code: http.StatusOK},
+			removed:  true,
+		},
+		{
+			name:     "by id",
+			runner:   "3",
+			expected: &JSONResultError{Code: http.StatusOK},
+			removed:  true,
+		},
+		{
+			name:   "missing runner",
+			runner: "",
+			expected: &JSONResultError{
+				Code:      http.StatusBadRequest,
+				Error:     "Missing required parameter: runner",
+				ErrorCode: ErrorCodeBadRequest,
+			},
+		},
+		{
+			name:   "runner in another group",
+			runner: "other-runner",
+			expected: &JSONResultError{
+				Code:      http.StatusNotFound,
+				Error:     "Unable to locate runner other-runner in the runner group of the team",
+				ErrorCode: ErrorCodeNotFound,
+			},
+		},
+		{
+			name:   "busy runner",
+			runner: "fake-runner",
+			busy:   true,
+			expected: &JSONResultError{
+				Code:      http.StatusConflict,
+				Error:     "Runner fake-runner is running a job and cannot be removed",
+				ErrorCode: ErrorCodeConflict,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		actionsClient := &mocks.ActionsClient{}
+		actionsClient.GetOrganizationRunnerReturns(&github.Runner{ID: github.Int64(3), Name: github.String("fake-runner"), Busy: github.Bool(tc.busy)}, &github.Response{}, nil)
+		actionsClient.RemoveOrganizationRunnerReturns(&github.Response{}, nil)
+		manager := newRunnerManager(actionsClient)
+		sink, err := NewFileAuditSink(filepath.Join(t.TempDir(), "audit.jsonl"))
+		require.NoError(t, err, tc.name)
+		t.Cleanup(func() { sink.Close() })
+		manager.AuditLog = sink
+
+		writer := httptest.NewRecorder()
+		request, err := http.NewRequest(http.MethodDelete, "/api/v1/runner-delete?team=fake-team&runner="+tc.runner, nil)
+		require.NoError(t, err, tc.name)
+		request.Header.Set("Authorization", "test-token")
+		manager.Router.ServeHTTP(writer, request)
+
+		require.Equal(t, tc.expected.Code, writer.Code, tc.name)
+		if tc.expected.Error != "" {
+			response := &JSONResultError{}
+			require.NoError(t, json.Unmarshal(writer.Body.Bytes(), response), tc.name)
+			require.Equal(t, tc.expected, response, tc.name)
+		}
+		if !tc.removed {
+			require.Equal(t, 0, actionsClient.RemoveOrganizationRunnerCallCount(), tc.name)
+			continue
+		}
+		require.Equal(t, 1, actionsClient.RemoveOrganizationRunnerCallCount(), tc.name)
+		_, org, runnerID := actionsClient.RemoveOrganizationRunnerArgsForCall(0)
+		require.Equal(t, "fake-org", org, tc.name)
+		require.Equal(t, int64(3), runnerID, tc.name)
+
+		events, err := sink.Query(&AuditFilter{Team: "fake-team"})
+		require.NoError(t, err, tc.name)
+		require.Len(t, events, 1, tc.name)
+		require.Equal(t, OperationRunnerDelete, events[0].Operation, tc.name)
+		require.Equal(t, "fake-runner", events[0].Runner, tc.name)
+	}
+}
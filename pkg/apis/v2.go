@@ -0,0 +1,338 @@
+/**
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package apis
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// v2GroupRequest is the body of the v2 requests that create or update the runner group of a team. Fields that are not
+// set keep their default when creating and are left unchanged when updating, and an empty list of workflows lifts the
+// workflow restriction.
+type v2GroupRequest struct {
+	Visibility               *string   `json:"visibility" binding:"omitempty,oneof=selected private all"`
+	AllowsPublicRepositories *bool     `json:"allowsPublicRepositories"`
+	Workflows                *[]string `json:"workflows" binding:"omitempty,dive,required,excludes=0x2C"`
+}
+
+// v2RepositoriesRequest is the body of the v2 requests that add repositories to or replace the repositories of the
+// runner group of a team
+type v2RepositoriesRequest struct {
+	Repositories []string `json:"repositories" binding:"required,min=1,dive,required,excludes=0x2C"`
+}
+
+// v2RunnerRequest is the body of the v2 request that registers a just-in-time runner in the runner group of a team
+type v2RunnerRequest struct {
+	Name   string   `json:"name" binding:"required"`
+	Labels []string `json:"labels" binding:"dive,required,excludes=0x2C"`
+	Work   string   `json:"work"`
+}
+
+// v2Binder translates the path parameters and JSON body of a v2 request into the query parameters of the v1 handler
+// serving it, writing the error response and returning false if the request does not match its schema
+type v2Binder func(c *gin.Context, query url.Values) bool
+
+// setV2Routes registers the resource oriented v2 API routes that act on the runner groups of a team in the organization
+// of the manager. Each route is served by the v1 handler of its operation, so both versions share their validation,
+// policy, auditing and responses. The group and dry_run query parameters are accepted as in v1.
+func (m *Manager) setV2Routes(group *gin.RouterGroup) {
+	teams := group.Group("/teams/:team", LimitHandler(m.Limit), m.RequireTeamPath, m.RequireToken)
+	{
+		teams.GET("/runner-group", m.RequirePermission(OperationGroupList), m.DoV2GroupList)
+		teams.POST("/runner-group", m.RequirePermission(OperationGroupCreate), bindV2(bindV2Group), m.Audit, m.DoV2GroupCreate)
+		teams.PATCH("/runner-group", m.RequirePermission(OperationGroupUpdate), bindV2(bindV2Group), m.Audit, m.DoV2GroupUpdate)
+		teams.DELETE("/runner-group", m.RequirePermission(OperationGroupDelete), m.Audit, m.DoV2GroupDelete)
+		teams.POST("/runner-group/repositories", m.RequirePermission(OperationReposAdd), bindV2(bindV2Repositories), m.Audit, m.DoV2ReposAdd)
+		teams.PUT("/runner-group/repositories", m.RequirePermission(OperationReposSet), bindV2(bindV2Repositories), m.Audit, m.DoV2ReposSet)
+		teams.PUT("/runner-group/repositories/:repo", m.RequirePermission(OperationReposAdd), bindV2(bindV2Repository), m.Audit, m.DoV2RepoAdd)
+		teams.DELETE("/runner-group/repositories/:repo", m.RequirePermission(OperationReposRemove), bindV2(bindV2Repository), m.Audit, m.DoV2RepoRemove)
+		teams.GET("/runner-group/runners", m.RequirePermission(OperationRunnerList), m.DoV2RunnerList)
+		teams.POST("/runner-group/runners", m.RequirePermission(OperationRunnerRegister), bindV2(bindV2Runner), m.Audit, m.DoV2RunnerRegister)
+		teams.DELETE("/runner-group/runners/:runner", m.RequirePermission(OperationRunnerDelete), bindV2(bindV2RunnerPath), m.Audit, m.DoV2RunnerDelete)
+		teams.GET("/runner-groups", m.RequirePermission(OperationGroupsList), m.DoV2GroupsList)
+	}
+}
+
+// bindV2 returns the middleware that rewrites the query of a v2 request with the binder, so the Audit middleware and
+// v1 handler that follow it read the translated parameters. Gin caches the query the first time it is read, so nothing
+// before the binder may read it, which TestV2Routes pins by sending query parameters the binder must override.
+func bindV2(bind v2Binder) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		query := c.Request.URL.Query()
+		if !bind(c, query) {
+			return
+		}
+		c.Request.URL.RawQuery = query.Encode()
+	}
+}
+
+// bindV2Body decodes and validates the JSON body of a v2 request, aborting the request if it does not match its schema
+func bindV2Body(c *gin.Context, body interface{}) bool {
+	if err := c.ShouldBindJSON(body); err != nil {
+		writeError(c, http.StatusBadRequest, ErrorCodeBadRequest, fmt.Sprintf("Invalid request body: %v", err))
+		c.Abort()
+		return false
+	}
+	return true
+}
+
+// bindV2Group translates the body of a request that creates or updates a runner group
+func bindV2Group(c *gin.Context, query url.Values) bool {
+	body := &v2GroupRequest{}
+	if !bindV2Body(c, body) {
+		return false
+	}
+	if body.Visibility != nil {
+		query.Set("visibility", *body.Visibility)
+	}
+	if body.AllowsPublicRepositories != nil {
+		query.Set("allowsPublicRepositories", strconv.FormatBool(*body.AllowsPublicRepositories))
+	}
+	if body.Workflows != nil {
+		query.Set("workflows", strings.Join(*body.Workflows, ","))
+	}
+	return true
+}
+
+// bindV2Repositories translates the body of a request that adds or replaces repositories
+func bindV2Repositories(c *gin.Context, query url.Values) bool {
+	body := &v2RepositoriesRequest{}
+	if !bindV2Body(c, body) {
+		return false
+	}
+	query.Set("repos", strings.Join(body.Repositories, ","))
+	return true
+}
+
+// bindV2Repository translates the repository path parameter of a request that adds or removes a single repository
+func bindV2Repository(c *gin.Context, query url.Values) bool {
+	repo := c.Param("repo")
+	// The v1 handlers split the repos parameter on commas, so a single repository must not contain one
+	if strings.Contains(repo, ",") {
+		writeError(c, http.StatusBadRequest, ErrorCodeBadRequest, fmt.Sprintf("Invalid repository %s, must not contain a comma", repo))
+		c.Abort()
+		return false
+	}
+	query.Set("repos", repo)
+	return true
+}
+
+// bindV2Runner translates the body of a request that registers a runner
+func bindV2Runner(c *gin.Context, query url.Values) bool {
+	body := &v2RunnerRequest{}
+	if !bindV2Body(c, body) {
+		return false
+	}
+	query.Set("name", body.Name)
+	if len(body.Labels) > 0 {
+		query.Set("labels", strings.Join(body.Labels, ","))
+	}
+	if body.Work != "" {
+		query.Set("work", body.Work)
+	}
+	return true
+}
+
+// bindV2RunnerPath translates the runner path parameter of a request that removes a runner
+func bindV2RunnerPath(c *gin.Context, query url.Values) bool {
+	query.Set("runner", c.Param("runner"))
+	return true
+}
+
+// DoV2GroupsList List every GitHub Action organization Runner Group of a team
+// @Summary      List every GitHub Action organization Runner Group of a team
+// @Description  Lists the runner groups of the team, as the v1 groups-list API
+// @Tags         Groups
+// @Produce      json
+// @Param        team  path      string  true  "Canonical **slug** of the GitHub team"
+// @Success      200   {object}  JSONResultSuccess{Code=int,Response=[]teamGroup}
+// @Router       /v2/teams/{team}/runner-groups [get]
+// @Security     ApiKeyAuth
+func (m *Manager) DoV2GroupsList(c *gin.Context) {
+	m.DoGroupsList(c)
+}
+
+// DoV2GroupList List all resources associated with a GitHub Action organization Runner Group
+// @Summary      List all resources associated with a GitHub Action organization Runner Group
+// @Description  List all repositories and runners assigned to the runner group of the team, as the v1 group-list API
+// @Tags         Groups
+// @Produce      json
+// @Param        team   path      string  true   "Canonical **slug** of the GitHub team"
+// @Param        group  query     string  false  "Suffix of the runner group of the team, selects its default runner group when unset"
+// @Success      200    {object}  JSONResultSuccess{Code=int,Response=listResponse}
+// @Router       /v2/teams/{team}/runner-group [get]
+// @Security     ApiKeyAuth
+func (m *Manager) DoV2GroupList(c *gin.Context) {
+	m.DoGroupList(c)
+}
+
+// DoV2GroupCreate Create a new GitHub Action organization Runner Group
+// @Summary      Create a new GitHub Action organization Runner Group
+// @Description  Creates the runner group of the team with the settings of the body, as the v1 group-create API
+// @Tags         Groups
+// @Accept       json
+// @Produce      json
+// @Param        team     path      string          true   "Canonical **slug** of the GitHub team"
+// @Param        group    query     string          false  "Suffix of the runner group of the team, selects its default runner group when unset"
+// @Param        dry_run  query     bool            false  "Validate the request and return the change it would make without making it"
+// @Param        body     body      v2GroupRequest  false  "Settings of the runner group"
+// @Success      200      {object}  JSONResultSuccess{Code=int,Response=string}
+// @Router       /v2/teams/{team}/runner-group [post]
+// @Security     ApiKeyAuth
+func (m *Manager) DoV2GroupCreate(c *gin.Context) {
+	m.DoGroupCreate(c)
+}
+
+// DoV2GroupUpdate Update the settings of an existing GitHub Action organization Runner Group
+// @Summary      Update the settings of an existing GitHub Action organization Runner Group
+// @Description  Updates the settings of the body on the runner group of the team, leaving the others unchanged, as the v1 group-update API
+// @Tags         Groups
+// @Accept       json
+// @Produce      json
+// @Param        team     path      string          true   "Canonical **slug** of the GitHub team"
+// @Param        group    query     string          false  "Suffix of the runner group of the team, selects its default runner group when unset"
+// @Param        dry_run  query     bool            false  "Validate the request and return the change it would make without making it"
+// @Param        body     body      v2GroupRequest  false  "Settings of the runner group"
+// @Success      200      {object}  JSONResultSuccess{Code=int,Response=string}
+// @Router       /v2/teams/{team}/runner-group [patch]
+// @Security     ApiKeyAuth
+func (m *Manager) DoV2GroupUpdate(c *gin.Context) {
+	m.DoGroupUpdate(c)
+}
+
+// DoV2GroupDelete Deletes an existing GitHub Action organization Runner Group
+// @Summary      Deletes an existing GitHub Action organization Runner Group
+// @Description  Deletes the runner group of the team, as the v1 group-delete API
+// @Tags         Groups
+// @Produce      json
+// @Param        team     path      string  true   "Canonical **slug** of the GitHub team"
+// @Param        group    query     string  false  "Suffix of the runner group of the team, selects its default runner group when unset"
+// @Param        dry_run  query     bool    false  "Validate the request and return the change it would make without making it"
+// @Success      200      {object}  JSONResultSuccess{Code=int,Response=string}
+// @Router       /v2/teams/{team}/runner-group [delete]
+// @Security     ApiKeyAuth
+func (m *Manager) DoV2GroupDelete(c *gin.Context) {
+	m.DoGroupDelete(c)
+}
+
+// DoV2ReposAdd Add new repositories to an existing GitHub Actions organization runner group
+// @Summary      Add new repositories to an existing GitHub Actions organization runner group
+// @Description  Adds the repositories of the body to the runner group of the team, as the v1 repos-add API
+// @Tags         Repos
+// @Accept       json
+// @Produce      json
+// @Param        team     path      string                 true   "Canonical **slug** of the GitHub team"
+// @Param        group    query     string                 false  "Suffix of the runner group of the team, selects its default runner group when unset"
+// @Param        dry_run  query     bool                   false  "Validate the request and return the change it would make without making it"
+// @Param        body     body      v2RepositoriesRequest  true   "Repositories to add"
+// @Success      200      {object}  JSONResultSuccess{Code=int,Response=[]repoResult}
+// @Success      207      {object}  JSONResultSuccess{Code=int,Response=[]repoResult}
+// @Router       /v2/teams/{team}/runner-group/repositories [post]
+// @Security     ApiKeyAuth
+func (m *Manager) DoV2ReposAdd(c *gin.Context) {
+	m.DoReposAdd(c)
+}
+
+// DoV2ReposSet Replaces all existing repositories in an existing GitHub Actions organization runner group with a new set of repositories
+// @Summary      Replaces all existing repositories in an existing GitHub Actions organization runner group with a new set of repositories
+// @Description  Replaces the repositories of the runner group of the team with the repositories of the body, as the v1 repos-set API
+// @Tags         Repos
+// @Accept       json
+// @Produce      json
+// @Param        team     path      string                 true   "Canonical **slug** of the GitHub team"
+// @Param        group    query     string                 false  "Suffix of the runner group of the team, selects its default runner group when unset"
+// @Param        dry_run  query     bool                   false  "Validate the request and return the change it would make without making it"
+// @Param        body     body      v2RepositoriesRequest  true   "Repositories of the runner group"
+// @Success      200      {object}  JSONResultSuccess{Code=int,Response=string}
+// @Router       /v2/teams/{team}/runner-group/repositories [put]
+// @Security     ApiKeyAuth
+func (m *Manager) DoV2ReposSet(c *gin.Context) {
+	m.DoReposSet(c)
+}
+
+// DoV2RepoAdd Add a repository to an existing GitHub Actions organization runner group
+// @Summary      Add a repository to an existing GitHub Actions organization runner group
+// @Description  Adds the repository of the path to the runner group of the team, as the v1 repos-add API
+// @Tags         Repos
+// @Produce      json
+// @Param        team     path      string  true   "Canonical **slug** of the GitHub team"
+// @Param        repo     path      string  true   "Slug of the repository"
+// @Param        group    query     string  false  "Suffix of the runner group of the team, selects its default runner group when unset"
+// @Param        dry_run  query     bool    false  "Validate the request and return the change it would make without making it"
+// @Success      200      {object}  JSONResultSuccess{Code=int,Response=[]repoResult}
+// @Success      207      {object}  JSONResultSuccess{Code=int,Response=[]repoResult}
+// @Router       /v2/teams/{team}/runner-group/repositories/{repo} [put]
+// @Security     ApiKeyAuth
+func (m *Manager) DoV2RepoAdd(c *gin.Context) {
+	m.DoReposAdd(c)
+}
+
+// DoV2RepoRemove Remove a repository from an existing GitHub Actions organization runner group
+// @Summary      Remove a repository from an existing GitHub Actions organization runner group
+// @Description  Removes the repository of the path from the runner group of the team, as the v1 repos-remove API
+// @Tags         Repos
+// @Produce      json
+// @Param        team     path      string  true   "Canonical **slug** of the GitHub team"
+// @Param        repo     path      string  true   "Slug of the repository"
+// @Param        group    query     string  false  "Suffix of the runner group of the team, selects its default runner group when unset"
+// @Param        dry_run  query     bool    false  "Validate the request and return the change it would make without making it"
+// @Success      200      {object}  JSONResultSuccess{Code=int,Response=[]repoResult}
+// @Success      207      {object}  JSONResultSuccess{Code=int,Response=[]repoResult}
+// @Router       /v2/teams/{team}/runner-group/repositories/{repo} [delete]
+// @Security     ApiKeyAuth
+func (m *Manager) DoV2RepoRemove(c *gin.Context) {
+	m.DoReposRemove(c)
+}
+
+// DoV2RunnerList List the runners of a GitHub Actions organization runner group
+// @Summary      List the runners of a GitHub Actions organization runner group
+// @Description  Lists the runners of the runner group of the team, as the v1 runner-list API
+// @Tags         Runners
+// @Produce      json
+// @Param        team   path      string  true   "Canonical **slug** of the GitHub team"
+// @Param        group  query     string  false  "Suffix of the runner group of the team, selects its default runner group when unset"
+// @Success      200    {object}  JSONResultSuccess{Code=int,Response=[]runnerDetails}
+// @Router       /v2/teams/{team}/runner-group/runners [get]
+// @Security     ApiKeyAuth
+func (m *Manager) DoV2RunnerList(c *gin.Context) {
+	m.DoRunnerList(c)
+}
+
+// DoV2RunnerRegister Register a GitHub Actions runner into the runner group of the team
+// @Summary      Register a GitHub Actions runner into the runner group of the team
+// @Description  Generates a just-in-time runner configuration bound to the runner group of the team, as the v1 runner-register API
+// @Tags         Runners
+// @Accept       json
+// @Produce      json
+// @Param        team   path      string           true   "Canonical **slug** of the GitHub team"
+// @Param        group  query     string           false  "Suffix of the runner group of the team, selects its default runner group when unset"
+// @Param        body   body      v2RunnerRequest  true   "Name, additional labels and working directory of the runner"
+// @Success      200    {object}  JSONResultSuccess{Code=int,Response=JITRunnerConfig}
+// @Router       /v2/teams/{team}/runner-group/runners [post]
+// @Security     ApiKeyAuth
+func (m *Manager) DoV2RunnerRegister(c *gin.Context) {
+	m.DoRunnerRegister(c)
+}
+
+// DoV2RunnerDelete Delete a runner from a GitHub Actions organization runner group
+// @Summary      Delete a runner from a GitHub Actions organization runner group
+// @Description  Removes the runner of the path after confirming it belongs to the runner group of the team, as the v1 runner-delete API
+// @Tags         Runners
+// @Produce      json
+// @Param        team    path      string  true   "Canonical **slug** of the GitHub team"
+// @Param        runner  path      string  true   "Name or ID of the runner"
+// @Param        group   query     string  false  "Suffix of the runner group of the team, selects its default runner group when unset"
+// @Success      200     {object}  JSONResultSuccess{Code=int,Response=string}
+// @Router       /v2/teams/{team}/runner-group/runners/{runner} [delete]
+// @Security     ApiKeyAuth
+func (m *Manager) DoV2RunnerDelete(c *gin.Context) {
+	m.DoRunnerDelete(c)
+}
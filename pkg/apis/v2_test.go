@@ -0,0 +1,216 @@
+/**
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package apis
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/v41/github"
+	"github.com/lindluni/actions-runner-manager/pkg/apis/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestV2Routes(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		code   int
+		error  string
+		check  func(*testing.T, *mocks.ActionsClient)
+	}{
+		{
+			name:   "create group",
+			method: http.MethodPost,
+			path:   "/api/v2/teams/other-team/runner-group",
+			body:   `{"visibility": "selected"}`,
+			code:   http.StatusOK,
+			check: func(t *testing.T, actionsClient *mocks.ActionsClient) {
+				require.Equal(t, 1, actionsClient.CreateOrganizationRunnerGroupCallCount())
+				_, org, createReq := actionsClient.CreateOrganizationRunnerGroupArgsForCall(0)
+				require.Equal(t, "fake-org", org)
+				require.Equal(t, "other-team", createReq.GetName())
+				require.Equal(t, VisibilitySelected, createReq.GetVisibility())
+			},
+		},
+		{
+			name:   "create group ignores visibility query",
+			method: http.MethodPost,
+			path:   "/api/v2/teams/other-team/runner-group?visibility=private",
+			body:   `{"visibility": "selected"}`,
+			code:   http.StatusOK,
+			check: func(t *testing.T, actionsClient *mocks.ActionsClient) {
+				require.Equal(t, 1, actionsClient.CreateOrganizationRunnerGroupCallCount())
+				_, _, createReq := actionsClient.CreateOrganizationRunnerGroupArgsForCall(0)
+				require.Equal(t, VisibilitySelected, createReq.GetVisibility())
+			},
+		},
+		{
+			name:   "create group with invalid visibility",
+			method: http.MethodPost,
+			path:   "/api/v2/teams/other-team/runner-group",
+			body:   `{"visibility": "internal"}`,
+			code:   http.StatusBadRequest,
+			error:  "Invalid request body",
+		},
+		{
+			name:   "create group with malformed body",
+			method: http.MethodPost,
+			path:   "/api/v2/teams/other-team/runner-group",
+			body:   `{"visibility": `,
+			code:   http.StatusBadRequest,
+			error:  "Invalid request body",
+		},
+		{
+			name:   "list groups",
+			method: http.MethodGet,
+			path:   "/api/v2/teams/fake-team/runner-groups",
+			code:   http.StatusOK,
+		},
+		{
+			name:   "add repositories",
+			method: http.MethodPost,
+			path:   "/api/v2/teams/fake-team/runner-group/repositories",
+			body:   `{"repositories": ["new", "present"]}`,
+			code:   http.StatusOK,
+			check: func(t *testing.T, actionsClient *mocks.ActionsClient) {
+				require.Equal(t, 1, actionsClient.AddRepositoryAccessRunnerGroupCallCount())
+				_, _, groupID, repoID := actionsClient.AddRepositoryAccessRunnerGroupArgsForCall(0)
+				require.Equal(t, int64(2), groupID)
+				require.Equal(t, int64(2), repoID)
+			},
+		},
+		{
+			name:   "add repositories without repositories",
+			method: http.MethodPost,
+			path:   "/api/v2/teams/fake-team/runner-group/repositories",
+			body:   `{"repositories": []}`,
+			code:   http.StatusBadRequest,
+			error:  "Invalid request body",
+		},
+		{
+			name:   "add repositories with comma in name",
+			method: http.MethodPut,
+			path:   "/api/v2/teams/fake-team/runner-group/repositories",
+			body:   `{"repositories": ["new,present"]}`,
+			code:   http.StatusBadRequest,
+			error:  "Invalid request body",
+		},
+		{
+			name:   "add repository",
+			method: http.MethodPut,
+			path:   "/api/v2/teams/fake-team/runner-group/repositories/new",
+			code:   http.StatusOK,
+			check: func(t *testing.T, actionsClient *mocks.ActionsClient) {
+				require.Equal(t, 1, actionsClient.AddRepositoryAccessRunnerGroupCallCount())
+			},
+		},
+		{
+			name:   "add repository with comma in name",
+			method: http.MethodPut,
+			path:   "/api/v2/teams/fake-team/runner-group/repositories/new%2Cpresent",
+			code:   http.StatusBadRequest,
+			error:  "Invalid repository new,present, must not contain a comma",
+		},
+		{
+			// The binder must rewrite the query before anything reads it, or the repos query parameter would be used
+			name:   "add repository ignores repos query",
+			method: http.MethodPut,
+			path:   "/api/v2/teams/fake-team/runner-group/repositories/new?repos=present",
+			code:   http.StatusOK,
+			check: func(t *testing.T, actionsClient *mocks.ActionsClient) {
+				require.Equal(t, 1, actionsClient.AddRepositoryAccessRunnerGroupCallCount())
+				_, _, _, repoID := actionsClient.AddRepositoryAccessRunnerGroupArgsForCall(0)
+				require.Equal(t, int64(2), repoID)
+			},
+		},
+		{
+			name:   "remove repository as dry run",
+			method: http.MethodDelete,
+			path:   "/api/v2/teams/fake-team/runner-group/repositories/present?dry_run=true",
+			code:   http.StatusOK,
+			check: func(t *testing.T, actionsClient *mocks.ActionsClient) {
+				require.Equal(t, 0, actionsClient.RemoveRepositoryAccessRunnerGroupCallCount())
+			},
+		},
+		{
+			name:   "register runner without name",
+			method: http.MethodPost,
+			path:   "/api/v2/teams/fake-team/runner-group/runners",
+			body:   `{"labels": ["gpu"]}`,
+			code:   http.StatusBadRequest,
+			error:  "Invalid request body",
+		},
+		{
+			name:   "delete runner",
+			method: http.MethodDelete,
+			path:   "/api/v2/teams/fake-team/runner-group/runners/fake-runner",
+			code:   http.StatusOK,
+			check: func(t *testing.T, actionsClient *mocks.ActionsClient) {
+				require.Equal(t, 1, actionsClient.RemoveOrganizationRunnerCallCount())
+				_, _, runnerID := actionsClient.RemoveOrganizationRunnerArgsForCall(0)
+				require.Equal(t, int64(3), runnerID)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		actionsClient := &mocks.ActionsClient{}
+		manager := newReposManager(actionsClient)
+		actionsClient.CreateOrganizationRunnerGroupCalls(func(_ context.Context, _ string, req github.CreateRunnerGroupRequest) (*github.RunnerGroup, *github.Response, error) {
+			return &github.RunnerGroup{ID: github.Int64(7), Name: req.Name}, &github.Response{}, nil
+		})
+		actionsClient.AddRepositoryAccessRunnerGroupReturns(&github.Response{Response: &http.Response{StatusCode: http.StatusNoContent}}, nil)
+		actionsClient.GetOrganizationRunnerReturns(&github.Runner{ID: github.Int64(3), Name: github.String("fake-runner")}, &github.Response{}, nil)
+		actionsClient.RemoveOrganizationRunnerReturns(&github.Response{Response: &http.Response{StatusCode: http.StatusNoContent}}, nil)
+
+		writer := httptest.NewRecorder()
+		request, err := http.NewRequest(tc.method, tc.path, strings.NewReader(tc.body))
+		require.NoError(t, err, tc.name)
+		request.Header.Set("Authorization", "test-token")
+		request.Header.Set("Content-Type", "application/json")
+		manager.Router.ServeHTTP(writer, request)
+		require.Equal(t, tc.code, writer.Code, tc.name)
+
+		if tc.error != "" {
+			response := &JSONResultError{}
+			require.NoError(t, json.Unmarshal(writer.Body.Bytes(), response), tc.name)
+			require.Contains(t, response.Error, tc.error, tc.name)
+			require.Equal(t, 0, actionsClient.ListOrganizationRunnerGroupsCallCount(), tc.name)
+		}
+		if tc.check != nil {
+			tc.check(t, actionsClient)
+		}
+	}
+}
+
+func TestV2Routes_RunnerList(t *testing.T) {
+	t.Parallel()
+
+	actionsClient := &mocks.ActionsClient{}
+	manager := newRunnerManager(actionsClient)
+
+	writer := httptest.NewRecorder()
+	request, err := http.NewRequest(http.MethodGet, "/api/v2/teams/fake-team/runner-group/runners", nil)
+	require.NoError(t, err)
+	request.Header.Set("Authorization", "test-token")
+	manager.Router.ServeHTTP(writer, request)
+	require.Equal(t, http.StatusOK, writer.Code)
+
+	response := &struct {
+		Code     int
+		Response []*runnerDetails
+	}{}
+	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), response))
+	require.Len(t, response.Response, 2)
+}
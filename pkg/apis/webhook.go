@@ -0,0 +1,235 @@
+/**
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package apis
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/go-github/v41/github"
+)
+
+const (
+	webhookUser = "webhook"
+
+	orphanedOperation = "group-orphaned"
+)
+
+// DoWebhook validates the X-Hub-Signature-256 header of a GitHub App webhook delivery and dispatches team, membership,
+// repository and installation events to their handlers
+func (m *Manager) DoWebhook(c *gin.Context) {
+	uuid := github.DeliveryID(c.Request)
+
+	m.Logger.WithField("uuid", uuid).Info("Validating webhook signature")
+	signature := c.GetHeader(github.SHA256SignatureHeader)
+	if signature == "" {
+		writeError(c, http.StatusUnauthorized, ErrorCodeUnauthenticated, fmt.Sprintf("Missing %s header", github.SHA256SignatureHeader))
+		return
+	}
+	payload, err := github.ValidatePayloadFromBody(c.ContentType(), c.Request.Body, signature, []byte(m.Config.Webhook.Secret))
+	if err != nil {
+		writeError(c, http.StatusUnauthorized, ErrorCodeUnauthenticated, fmt.Sprintf("Unable to validate webhook signature: %v", err))
+		return
+	}
+	m.Logger.WithField("uuid", uuid).Debug("Validated webhook signature")
+
+	eventType := github.WebHookType(c.Request)
+	m.Logger.WithField("uuid", uuid).Infof("Parsing %s webhook event", eventType)
+	event, err := github.ParseWebHook(eventType, payload)
+	if err != nil {
+		writeError(c, http.StatusBadRequest, ErrorCodeBadRequest, fmt.Sprintf("Unable to parse webhook event: %v", err))
+		return
+	}
+	m.Logger.WithField("uuid", uuid).Debugf("Parsed %s webhook event", eventType)
+
+	var handled bool
+	switch event := event.(type) {
+	case *github.TeamEvent:
+		handled, err = m.webhookOrganization(event.GetOrg()).handleTeamEvent(event, uuid)
+	case *github.MembershipEvent:
+		handled = m.webhookOrganization(event.GetOrg()).handleMembershipEvent(event, uuid)
+	case *github.RepositoryEvent:
+		handled = m.webhookOrganization(event.GetOrg()).handleRepositoryEvent(event, uuid)
+	case *github.InstallationEvent:
+		for _, org := range m.managedOrganizations() {
+			if org.handleInstallationEvent(event, uuid) {
+				handled = true
+			}
+		}
+	}
+	if err != nil {
+		writeError(c, http.StatusInternalServerError, ErrorCodeInternal, fmt.Sprintf("Unable to handle %s webhook event: %v", eventType, err))
+		return
+	}
+
+	response := fmt.Sprintf("Ignored %s webhook event", eventType)
+	if handled {
+		response = fmt.Sprintf("Handled %s webhook event", eventType)
+	}
+	c.JSON(http.StatusOK, &JSONResultSuccess{
+		Code:     http.StatusOK,
+		Response: response,
+	})
+}
+
+// handleTeamEvent removes repositories from the runner groups of a team when the team loses access to them, and flags
+// the runner group as orphaned when the team is deleted or renamed
+func (m *Manager) handleTeamEvent(event *github.TeamEvent, uuid string) (bool, error) {
+	if !m.isManagedOrg(event.GetOrg()) {
+		return false, nil
+	}
+	team := event.GetTeam().GetSlug()
+	switch event.GetAction() {
+	case "deleted":
+		m.Cache.Invalidate(team, "")
+		m.flagOrphanedGroup(team, event.GetSender(), uuid, fmt.Sprintf("team %s was deleted", team))
+		return true, nil
+	case "edited":
+		if event.GetChanges().GetName() == nil {
+			return false, nil
+		}
+		previous := event.GetChanges().GetName().GetFrom()
+		// The results are cached under the previous slug, which the event does not carry, and under the teams that
+		// inherit maintainership from the renamed team
+		m.Cache.InvalidateAll()
+		m.flagOrphanedGroup(team, event.GetSender(), uuid, fmt.Sprintf("team %s was renamed from %s", team, previous))
+		return true, nil
+	case "removed_from_repository":
+		repo := event.GetRepo()
+		ctx := context.Background()
+		m.Logger.WithField("uuid", uuid).WithField("team", team).Infof("Removing repository %s from runner groups", repo.GetName())
+		groups, err := m.listTeamGroups(ctx, team)
+		if err != nil {
+			return false, fmt.Errorf("unable to list runner groups: %w", err)
+		}
+		if len(groups) == 0 {
+			m.Logger.WithField("uuid", uuid).WithField("team", team).Debug("Team does not have a runner group, skipping")
+			return true, nil
+		}
+		for _, group := range groups {
+			resp, err := m.ActionsClient.RemoveRepositoryAccessRunnerGroup(ctx, m.Config.Org, group.GetID(), repo.GetID())
+			code := http.StatusNoContent
+			if resp != nil && resp.Response != nil {
+				code = resp.StatusCode
+			}
+			if err != nil && code != http.StatusNotFound {
+				return false, fmt.Errorf("unable to remove repository %s from runner group %s: %w", repo.GetName(), group.GetName(), err)
+			}
+			_, suffix, _ := m.Config.Groups.TeamFor(m.Config.Org, group.GetName())
+			m.writeWebhookAudit(&AuditEvent{
+				RequestID:   uuid,
+				User:        webhookSender(event.GetSender()),
+				Team:        team,
+				Group:       suffix,
+				Operation:   OperationReposRemove,
+				ReposBefore: []string{repo.GetName()},
+				ReposAfter:  []string{},
+				Code:        code,
+			})
+		}
+		m.Logger.WithField("uuid", uuid).WithField("team", team).Debugf("Removed repository %s from runner groups", repo.GetName())
+		return true, nil
+	}
+	return false, nil
+}
+
+// handleMembershipEvent drops the cached maintainership of a user whose team membership changed
+func (m *Manager) handleMembershipEvent(event *github.MembershipEvent, uuid string) bool {
+	if !m.isManagedOrg(event.GetOrg()) || event.GetScope() != "team" {
+		return false
+	}
+	team := event.GetTeam().GetSlug()
+	user := event.GetMember().GetLogin()
+	// With inherited maintainership, the membership also grants or revokes the maintainer role of every descendant team
+	if m.Config.Policy.InheritMaintainership {
+		m.Logger.WithField("uuid", uuid).WithField("team", team).Infof("Invalidating every cached maintainership of %s after membership was %s", user, event.GetAction())
+		m.Cache.InvalidateUser(user)
+		return true
+	}
+	m.Logger.WithField("uuid", uuid).WithField("team", team).Infof("Invalidating cached maintainership of %s after membership was %s", user, event.GetAction())
+	m.Cache.Invalidate(team, user)
+	return true
+}
+
+// handleRepositoryEvent logs repositories that are deleted, archived or transferred, as GitHub removes them from runner
+// groups on its own
+func (m *Manager) handleRepositoryEvent(event *github.RepositoryEvent, uuid string) bool {
+	if !m.isManagedOrg(event.GetOrg()) {
+		return false
+	}
+	switch event.GetAction() {
+	case "deleted", "archived", "transferred":
+		m.Logger.WithField("uuid", uuid).Infof("Repository %s was %s", event.GetRepo().GetFullName(), event.GetAction())
+		return true
+	}
+	return false
+}
+
+// handleInstallationEvent warns when the GitHub App installation the server relies on is removed or suspended
+func (m *Manager) handleInstallationEvent(event *github.InstallationEvent, uuid string) bool {
+	if event.GetInstallation().GetID() != m.Config.InstallationID {
+		return false
+	}
+	switch event.GetAction() {
+	case "deleted", "suspend":
+		m.Cache.InvalidateAll()
+		m.Logger.WithField("uuid", uuid).Errorf("GitHub App installation %d was %s by %s, API calls will fail until it is restored", m.Config.InstallationID, event.GetAction(), event.GetSender().GetLogin())
+		return true
+	case "unsuspend":
+		m.Logger.WithField("uuid", uuid).Infof("GitHub App installation %d was unsuspended", m.Config.InstallationID)
+		return true
+	}
+	return false
+}
+
+// flagOrphanedGroup records that the runner group named after the team no longer belongs to a team
+func (m *Manager) flagOrphanedGroup(team string, sender *github.User, uuid, reason string) {
+	m.Logger.WithField("uuid", uuid).WithField("team", team).Warnf("Runner group may be orphaned, %s", reason)
+	m.writeWebhookAudit(&AuditEvent{
+		RequestID: uuid,
+		User:      webhookSender(sender),
+		Team:      team,
+		Operation: orphanedOperation,
+		Code:      http.StatusOK,
+	})
+}
+
+// writeWebhookAudit records an audit event for a change made in response to a webhook when an audit log is configured
+func (m *Manager) writeWebhookAudit(event *AuditEvent) {
+	if m.AuditLog == nil {
+		return
+	}
+	event.Time = time.Now().UTC()
+	event.Org = m.Config.Org
+	if err := m.AuditLog.Write(event); err != nil {
+		m.Logger.WithField("uuid", event.RequestID).WithField("team", event.Team).Errorf("Unable to write audit event: %v", err)
+	}
+}
+
+// webhookOrganization returns the manager of the organization the event was delivered for, falling back to the default
+// manager, which ignores events of organizations it does not manage
+func (m *Manager) webhookOrganization(org *github.Organization) *Manager {
+	if manager := m.organization(org.GetLogin()); manager != nil {
+		return manager
+	}
+	return m
+}
+
+// isManagedOrg reports whether the event was delivered for the organization the server manages
+func (m *Manager) isManagedOrg(org *github.Organization) bool {
+	return strings.EqualFold(org.GetLogin(), m.Config.Org)
+}
+
+// webhookSender names the user who triggered a webhook event in audit events
+func webhookSender(sender *github.User) string {
+	if sender.GetLogin() == "" {
+		return webhookUser
+	}
+	return webhookUser + ":" + sender.GetLogin()
+}
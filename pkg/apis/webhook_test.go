@@ -0,0 +1,244 @@
+/**
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package apis
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/didip/tollbooth/v6"
+	"github.com/gin-gonic/gin"
+	"github.com/google/go-github/v41/github"
+	"github.com/lindluni/actions-runner-manager/pkg/apis/mocks"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+)
+
+func sendWebhook(t *testing.T, manager *Manager, eventType, secret string, event interface{}) (*JSONResultError, int) {
+	payload, err := json.Marshal(event)
+	require.NoError(t, err)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+
+	writer := httptest.NewRecorder()
+	request, err := http.NewRequest(http.MethodPost, "/webhooks/github", bytes.NewReader(payload))
+	require.NoError(t, err)
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(github.EventTypeHeader, eventType)
+	request.Header.Set(github.DeliveryIDHeader, "fake-delivery")
+	request.Header.Set(github.SHA256SignatureHeader, "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	manager.Router.ServeHTTP(writer, request)
+
+	result := writer.Result()
+	body, err := ioutil.ReadAll(result.Body)
+	defer result.Body.Close()
+	require.NoError(t, err)
+	response := &JSONResultError{}
+	require.NoError(t, json.Unmarshal(body, response))
+	return response, result.StatusCode
+}
+
+func newWebhookManager(t *testing.T, actionsClient *mocks.ActionsClient) (*Manager, AuditSink) {
+	sink, err := NewFileAuditSink(filepath.Join(t.TempDir(), "audit.jsonl"))
+	require.NoError(t, err)
+	t.Cleanup(func() { sink.Close() })
+	logger, _ := test.NewNullLogger()
+	manager := &Manager{
+		ActionsClient: actionsClient,
+		AuditLog:      sink,
+		Config: &Config{
+			Org:            "fake-org",
+			InstallationID: 1,
+			Webhook:        Webhook{Secret: "fake-secret"},
+		},
+		Limit:  tollbooth.NewLimiter(1, nil),
+		Logger: logger,
+		Router: gin.New(),
+	}
+	manager.SetRoutes()
+	return manager, sink
+}
+
+func TestWebhook_Signature(t *testing.T) {
+	t.Parallel()
+
+	manager, _ := newWebhookManager(t, &mocks.ActionsClient{})
+	response, code := sendWebhook(t, manager, "team", "wrong-secret", &github.TeamEvent{})
+	require.Equal(t, http.StatusUnauthorized, code)
+	require.Equal(t, "Unable to validate webhook signature: payload signature check failed", response.Error)
+
+	writer := httptest.NewRecorder()
+	request, err := http.NewRequest(http.MethodPost, "/webhooks/github", bytes.NewBufferString("{}"))
+	require.NoError(t, err)
+	request.Header.Set(github.EventTypeHeader, "team")
+	manager.Router.ServeHTTP(writer, request)
+	require.Equal(t, http.StatusUnauthorized, writer.Code)
+}
+
+func TestWebhook_TeamRemovedFromRepository(t *testing.T) {
+	t.Parallel()
+
+	actionsClient := &mocks.ActionsClient{}
+	actionsClient.ListOrganizationRunnerGroupsReturns(&github.RunnerGroups{
+		RunnerGroups: []*github.RunnerGroup{{ID: github.Int64(2), Name: github.String("fake-team")}},
+	}, &github.Response{}, nil)
+	actionsClient.RemoveRepositoryAccessRunnerGroupReturns(&github.Response{Response: &http.Response{StatusCode: http.StatusNoContent}}, nil)
+	manager, sink := newWebhookManager(t, actionsClient)
+
+	response, code := sendWebhook(t, manager, "team", "fake-secret", &github.TeamEvent{
+		Action: github.String("removed_from_repository"),
+		Team:   &github.Team{Slug: github.String("fake-team")},
+		Repo:   &github.Repository{ID: github.Int64(3), Name: github.String("fake-repo")},
+		Org:    &github.Organization{Login: github.String("fake-org")},
+		Sender: &github.User{Login: github.String("fake-user")},
+	})
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, http.StatusOK, response.Code)
+	require.Equal(t, 1, actionsClient.RemoveRepositoryAccessRunnerGroupCallCount())
+	_, org, groupID, repoID := actionsClient.RemoveRepositoryAccessRunnerGroupArgsForCall(0)
+	require.Equal(t, "fake-org", org)
+	require.Equal(t, int64(2), groupID)
+	require.Equal(t, int64(3), repoID)
+
+	events, err := sink.Query(&AuditFilter{Team: "fake-team"})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	require.Equal(t, "webhook:fake-user", events[0].User)
+	require.Equal(t, OperationReposRemove, events[0].Operation)
+
+	_, code = sendWebhook(t, manager, "team", "fake-secret", &github.TeamEvent{
+		Action: github.String("removed_from_repository"),
+		Team:   &github.Team{Slug: github.String("fake-team")},
+		Repo:   &github.Repository{ID: github.Int64(3), Name: github.String("fake-repo")},
+		Org:    &github.Organization{Login: github.String("other-org")},
+	})
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, 1, actionsClient.RemoveRepositoryAccessRunnerGroupCallCount())
+}
+
+func TestWebhook_TeamDeleted(t *testing.T) {
+	t.Parallel()
+
+	manager, sink := newWebhookManager(t, &mocks.ActionsClient{})
+	manager.Cache = NewMaintainershipCache(time.Minute, 0)
+	manager.Cache.Set("fake-token", "fake-team", &caller{login: "fake-user", roles: []string{RoleMaintainer}})
+
+	_, code := sendWebhook(t, manager, "team", "fake-secret", &github.TeamEvent{
+		Action: github.String("deleted"),
+		Team:   &github.Team{Slug: github.String("fake-team")},
+		Org:    &github.Organization{Login: github.String("fake-org")},
+	})
+	require.Equal(t, http.StatusOK, code)
+	_, ok := manager.Cache.Get("fake-token", "fake-team")
+	require.False(t, ok)
+
+	events, err := sink.Query(&AuditFilter{Team: "fake-team"})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	require.Equal(t, orphanedOperation, events[0].Operation)
+	require.Equal(t, webhookUser, events[0].User)
+}
+
+func TestWebhook_TeamRenamed(t *testing.T) {
+	t.Parallel()
+
+	manager, sink := newWebhookManager(t, &mocks.ActionsClient{})
+	manager.Cache = NewMaintainershipCache(time.Minute, 0)
+	manager.Cache.Set("fake-token", "fake-team", &caller{login: "fake-user", roles: []string{RoleMaintainer}})
+	manager.Cache.Set("fake-token", "child-team", &caller{login: "fake-user", roles: []string{RoleMaintainer}, ancestry: []string{"fake-team"}})
+
+	_, code := sendWebhook(t, manager, "team", "fake-secret", &github.TeamEvent{
+		Action:  github.String("edited"),
+		Changes: &github.TeamChange{Name: &github.TeamName{From: github.String("fake-team")}},
+		Team:    &github.Team{Slug: github.String("renamed-team")},
+		Org:     &github.Organization{Login: github.String("fake-org")},
+	})
+	require.Equal(t, http.StatusOK, code)
+	_, ok := manager.Cache.Get("fake-token", "fake-team")
+	require.False(t, ok, "results cached under the previous slug must be invalidated")
+	_, ok = manager.Cache.Get("fake-token", "child-team")
+	require.False(t, ok)
+
+	events, err := sink.Query(&AuditFilter{Team: "renamed-team"})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	require.Equal(t, orphanedOperation, events[0].Operation)
+}
+
+func TestWebhook_Membership(t *testing.T) {
+	t.Parallel()
+
+	manager, _ := newWebhookManager(t, &mocks.ActionsClient{})
+	manager.Cache = NewMaintainershipCache(time.Minute, 0)
+	manager.Cache.Set("fake-token", "fake-team", &caller{login: "fake-user", roles: []string{RoleMaintainer}})
+	manager.Cache.Set("other-token", "fake-team", &caller{login: "other-user", roles: []string{RoleMaintainer}})
+
+	_, code := sendWebhook(t, manager, "membership", "fake-secret", &github.MembershipEvent{
+		Action: github.String("removed"),
+		Scope:  github.String("team"),
+		Member: &github.User{Login: github.String("fake-user")},
+		Team:   &github.Team{Slug: github.String("fake-team")},
+		Org:    &github.Organization{Login: github.String("fake-org")},
+	})
+	require.Equal(t, http.StatusOK, code)
+	_, ok := manager.Cache.Get("fake-token", "fake-team")
+	require.False(t, ok)
+	_, ok = manager.Cache.Get("other-token", "fake-team")
+	require.True(t, ok)
+}
+
+func TestWebhook_MembershipInherited(t *testing.T) {
+	t.Parallel()
+
+	manager, _ := newWebhookManager(t, &mocks.ActionsClient{})
+	manager.Config.Policy.InheritMaintainership = true
+	manager.Cache = NewMaintainershipCache(time.Minute, 0)
+	manager.Cache.Set("fake-token", "parent-team", &caller{login: "fake-user", roles: []string{RoleMaintainer}})
+	manager.Cache.Set("fake-token", "fake-team", &caller{login: "fake-user", roles: []string{RoleMaintainer}, ancestry: []string{"parent-team"}})
+	manager.Cache.Set("other-token", "fake-team", &caller{login: "other-user", roles: []string{RoleMaintainer}})
+
+	_, code := sendWebhook(t, manager, "membership", "fake-secret", &github.MembershipEvent{
+		Action: github.String("removed"),
+		Scope:  github.String("team"),
+		Member: &github.User{Login: github.String("fake-user")},
+		Team:   &github.Team{Slug: github.String("parent-team")},
+		Org:    &github.Organization{Login: github.String("fake-org")},
+	})
+	require.Equal(t, http.StatusOK, code)
+	_, ok := manager.Cache.Get("fake-token", "parent-team")
+	require.False(t, ok)
+	_, ok = manager.Cache.Get("fake-token", "fake-team")
+	require.False(t, ok, "maintainership inherited from the parent team must be invalidated")
+	_, ok = manager.Cache.Get("other-token", "fake-team")
+	require.True(t, ok)
+}
+
+func TestWebhook_Unconfigured(t *testing.T) {
+	t.Parallel()
+
+	logger, _ := test.NewNullLogger()
+	manager := &Manager{
+		Config: &Config{},
+		Limit:  tollbooth.NewLimiter(1, nil),
+		Logger: logger,
+		Router: gin.New(),
+	}
+	manager.SetRoutes()
+
+	writer := httptest.NewRecorder()
+	request, err := http.NewRequest(http.MethodPost, "/webhooks/github", bytes.NewBufferString("{}"))
+	require.NoError(t, err)
+	manager.Router.ServeHTTP(writer, request)
+	require.Equal(t, http.StatusNotFound, writer.Code)
+}
@@ -0,0 +1,128 @@
+/**
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package apis
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-contrib/requestid"
+	"github.com/gin-gonic/gin"
+)
+
+type workflowsResponse struct {
+	Restricted bool     `json:"restricted"`
+	Workflows  []string `json:"workflows"`
+}
+
+// DoWorkflowsList List the workflows a GitHub Actions organization runner group is restricted to
+// @Summary      List the workflows a GitHub Actions organization runner group is restricted to
+// @Description  Lists whether the runner group named with the team slug is restricted to selected workflows, and the workflows it is restricted to
+// @Tags         Workflows
+// @Produce      json
+// @Param        team   query     string  true   "Canonical **slug** of the GitHub team"
+// @Param        group  query     string  false  "Suffix of the runner group of the team, selects its default runner group when unset"
+// @Success      200    {object}  JSONResultSuccess{Code=int,Response=workflowsResponse}
+// @Router       /v1/workflows-list [get]
+// @Security     ApiKeyAuth
+func (m *Manager) DoWorkflowsList(c *gin.Context) {
+	uuid := requestid.Get(c)
+	team := c.GetString(teamKey)
+
+	m.Logger.WithField("uuid", uuid).WithField("team", team).Info("Retrieving group parameter")
+	suffix, ok := m.retrieveGroupSuffix(c)
+	if !ok {
+		return
+	}
+	m.Logger.WithField("uuid", uuid).WithField("team", team).Debug("Retrieved group parameter")
+
+	m.Logger.WithField("uuid", uuid).WithField("team", team).Info("Retrieving runner group ID")
+	groupID, err := m.retrieveGroupID(team, suffix, uuid)
+	if err != nil {
+		writeAPIError(c, err, fmt.Sprintf("Unable to retrieve group ID: %v", err))
+		return
+	}
+	m.Logger.WithField("uuid", uuid).WithField("team", team).Debug("Retrieved runner group ID")
+
+	m.Logger.WithField("uuid", uuid).WithField("team", team).Info("Retrieving runner group workflows")
+	workflows, resp, err := m.getGroupWorkflows(context.Background(), *groupID)
+	if err != nil {
+		writeAPIError(c, gitHubError(resp, err), fmt.Sprintf("Unable to retrieve runner group workflows: %v", err))
+		return
+	}
+	m.Logger.WithField("uuid", uuid).WithField("team", team).Debug("Retrieved runner group workflows")
+
+	response := &workflowsResponse{
+		Restricted: workflows.RestrictedToWorkflows,
+		Workflows:  workflows.SelectedWorkflows,
+	}
+	if response.Workflows == nil {
+		response.Workflows = []string{}
+	}
+	c.JSON(http.StatusOK, &JSONResultSuccess{
+		Code:     http.StatusOK,
+		Response: response,
+	})
+}
+
+// DoWorkflowsSet Restrict a GitHub Actions organization runner group to selected workflows
+// @Summary      Restrict a GitHub Actions organization runner group to selected workflows
+// @Description  Replaces the workflows the runner group named with the team slug is restricted to. An empty workflows parameter lifts the restriction, unless the configuration requires every runner group to be restricted.
+// @Tags         Workflows
+// @Produce      json
+// @Param        team       query     string    true   "Canonical **slug** of the GitHub team"
+// @Param        group      query     string    false  "Suffix of the runner group of the team, selects its default runner group when unset"
+// @Param        workflows  query     []string  true   "Comma-seperated list of workflows, formatted as <owner>/<repo>/<path>@<ref>"
+// @Success      200        {object}  JSONResultSuccess{Code=int,Response=workflowsResponse}
+// @Router       /v1/workflows-set [patch]
+// @Security     ApiKeyAuth
+func (m *Manager) DoWorkflowsSet(c *gin.Context) {
+	uuid := requestid.Get(c)
+	team := c.GetString(teamKey)
+
+	m.Logger.WithField("uuid", uuid).WithField("team", team).Info("Retrieving workflows parameter")
+	param, ok := c.GetQuery("workflows")
+	if !ok {
+		writeError(c, http.StatusBadRequest, ErrorCodeBadRequest, "Missing required parameter: workflows")
+		return
+	}
+	workflows := m.retrieveWorkflows(c, param)
+	if workflows == nil {
+		return
+	}
+	m.Logger.WithField("uuid", uuid).WithField("team", team).Debug("Retrieved workflows parameter")
+
+	m.Logger.WithField("uuid", uuid).WithField("team", team).Info("Retrieving group parameter")
+	suffix, ok := m.retrieveGroupSuffix(c)
+	if !ok {
+		return
+	}
+	m.Logger.WithField("uuid", uuid).WithField("team", team).Debug("Retrieved group parameter")
+
+	m.Logger.WithField("uuid", uuid).WithField("team", team).Info("Retrieving runner group ID")
+	groupID, err := m.retrieveGroupID(team, suffix, uuid)
+	if err != nil {
+		writeAPIError(c, err, fmt.Sprintf("Unable to retrieve group ID: %v", err))
+		return
+	}
+	m.Logger.WithField("uuid", uuid).WithField("team", team).Debug("Retrieved runner group ID")
+
+	m.Logger.WithField("uuid", uuid).WithField("team", team).Infof("Restricting runner group to workflows %v", workflows)
+	resp, err := m.setGroupWorkflows(context.Background(), *groupID, workflows)
+	if err != nil {
+		writeAPIError(c, gitHubError(resp, err), fmt.Sprintf("Unable to restrict runner group workflows: %v", err))
+		return
+	}
+	m.Logger.WithField("uuid", uuid).WithField("team", team).Debug("Restricted runner group to workflows")
+
+	c.JSON(http.StatusOK, &JSONResultSuccess{
+		Code: http.StatusOK,
+		Response: &workflowsResponse{
+			Restricted: len(workflows) > 0,
+			Workflows:  workflows,
+		},
+	})
+}
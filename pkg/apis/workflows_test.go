@@ -0,0 +1,161 @@
+/**
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package apis
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-github/v41/github"
+	"github.com/lindluni/actions-runner-manager/pkg/apis/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+const platformWorkflow = "fake-org/platform/.github/workflows/build.yml@refs/heads/main"
+
+func TestGroups_AllowsWorkflows(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, Groups{}.AllowsWorkflows(nil))
+	require.NoError(t, Groups{}.AllowsWorkflows([]string{"other-org/repo/.github/workflows/build.yml@main"}))
+
+	groups := Groups{
+		RequireWorkflowRestriction: true,
+		AllowedWorkflows:           []string{"fake-org/platform/.github/workflows/*@refs/heads/main"},
+	}
+	require.NoError(t, groups.Validate())
+	require.NoError(t, groups.AllowsWorkflows([]string{platformWorkflow}))
+	require.EqualError(t, groups.AllowsWorkflows([]string{}), "runner groups must be restricted to at least one workflow")
+	require.EqualError(t, groups.AllowsWorkflows([]string{"fake-org/platform/.github/workflows/build.yml@feature"}), "workflow fake-org/platform/.github/workflows/build.yml@feature is not allowed")
+	require.EqualError(t, Groups{AllowedWorkflows: []string{"["}}.Validate(), "invalid allowed workflow pattern [: syntax error in pattern")
+}
+
+func TestDoWorkflowsList(t *testing.T) {
+	t.Parallel()
+
+	restClient := &mocks.RestClient{}
+	restClient.NewRequestReturns(&http.Request{}, nil)
+	restClient.DoStub = func(_ context.Context, _ *http.Request, v interface{}) (*github.Response, error) {
+		*v.(*runnerGroupWorkflows) = runnerGroupWorkflows{RestrictedToWorkflows: true, SelectedWorkflows: []string{platformWorkflow}}
+		return &github.Response{}, nil
+	}
+	manager := newRunnerManager(&mocks.ActionsClient{})
+	manager.RestClient = restClient
+
+	writer := httptest.NewRecorder()
+	request, err := http.NewRequest(http.MethodGet, "/api/v1/workflows-list?team=fake-team", nil)
+	require.NoError(t, err)
+	request.Header.Set("Authorization", "test-token")
+	manager.Router.ServeHTTP(writer, request)
+
+	require.Equal(t, http.StatusOK, writer.Code)
+	require.JSONEq(t, `{"Code":200,"Response":{"restricted":true,"workflows":["`+platformWorkflow+`"]}}`, writer.Body.String())
+	method, url, _ := restClient.NewRequestArgsForCall(0)
+	require.Equal(t, http.MethodGet, method)
+	require.Equal(t, "orgs/fake-org/actions/runner-groups/2", url)
+}
+
+func TestDoWorkflowsSet(t *testing.T) {
+	t.Parallel()
+
+	groups := Groups{
+		RequireWorkflowRestriction: true,
+		AllowedWorkflows:           []string{"fake-org/platform/.github/workflows/*@refs/heads/main"},
+	}
+	tests := []struct {
+		name      string
+		query     string
+		groups    Groups
+		code      int
+		error     string
+		workflows []string
+	}{
+		{
+			name:      "allowed",
+			query:     "&workflows=" + platformWorkflow,
+			groups:    groups,
+			code:      http.StatusOK,
+			workflows: []string{platformWorkflow},
+		},
+		{
+			name:      "lift restriction",
+			query:     "&workflows=",
+			code:      http.StatusOK,
+			workflows: []string{},
+		},
+		{
+			name:   "lift required restriction",
+			query:  "&workflows=",
+			groups: groups,
+			code:   http.StatusForbidden,
+			error:  "Workflows not allowed by policy: runner groups must be restricted to at least one workflow",
+		},
+		{
+			name:   "workflow not allowed",
+			query:  "&workflows=other-org/repo/.github/workflows/build.yml@main",
+			groups: groups,
+			code:   http.StatusForbidden,
+			error:  "Workflows not allowed by policy: workflow other-org/repo/.github/workflows/build.yml@main is not allowed",
+		},
+		{
+			name:  "malformed workflow",
+			query: "&workflows=build.yml",
+			code:  http.StatusBadRequest,
+			error: "Invalid workflow build.yml, must be <owner>/<repo>/<path>@<ref>",
+		},
+		{
+			name:  "missing workflows",
+			code:  http.StatusBadRequest,
+			error: "Missing required parameter: workflows",
+		},
+	}
+
+	for _, tc := range tests {
+		restClient := &mocks.RestClient{}
+		restClient.NewRequestReturns(&http.Request{}, nil)
+		manager := newRunnerManager(&mocks.ActionsClient{})
+		manager.RestClient = restClient
+		manager.Config.Groups = tc.groups
+
+		writer := httptest.NewRecorder()
+		request, err := http.NewRequest(http.MethodPatch, "/api/v1/workflows-set?team=fake-team"+tc.query, nil)
+		require.NoError(t, err, tc.name)
+		request.Header.Set("Authorization", "test-token")
+		manager.Router.ServeHTTP(writer, request)
+		require.Equal(t, tc.code, writer.Code, tc.name)
+
+		if tc.error != "" {
+			response := &JSONResultError{}
+			require.NoError(t, json.Unmarshal(writer.Body.Bytes(), response), tc.name)
+			require.Equal(t, tc.error, response.Error, tc.name)
+			require.Equal(t, 0, restClient.NewRequestCallCount(), tc.name)
+			continue
+		}
+		method, url, body := restClient.NewRequestArgsForCall(0)
+		require.Equal(t, http.MethodPatch, method, tc.name)
+		require.Equal(t, "orgs/fake-org/actions/runner-groups/2", url, tc.name)
+		require.Equal(t, &runnerGroupWorkflows{RestrictedToWorkflows: len(tc.workflows) > 0, SelectedWorkflows: tc.workflows}, body, tc.name)
+	}
+}
+
+func TestDoGroupCreate_RequireWorkflowRestriction(t *testing.T) {
+	t.Parallel()
+
+	actionsClient := &mocks.ActionsClient{}
+	manager := newRunnerManager(actionsClient)
+	manager.Config.Groups = Groups{RequireWorkflowRestriction: true}
+
+	writer := httptest.NewRecorder()
+	request, err := http.NewRequest(http.MethodPost, "/api/v1/group-create?team=fake-team", nil)
+	require.NoError(t, err)
+	request.Header.Set("Authorization", "test-token")
+	manager.Router.ServeHTTP(writer, request)
+
+	require.Equal(t, http.StatusForbidden, writer.Code)
+	require.Equal(t, 0, actionsClient.CreateOrganizationRunnerGroupCallCount())
+}
@@ -8,6 +8,7 @@ import (
 	"context"
 	"encoding/base64"
 	"encoding/json"
+	"flag"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -45,7 +46,7 @@ func init() {
 // @contact.email               lindluni@github.com
 // @license.name                Apache 2.0
 // @license.url                 http://www.apache.org/licenses/LICENSE-2.0.html
-// @BasePath                    /api/v1
+// @BasePath                    /api
 // @host                        localhost
 // @securityDefinitions.apikey  APIKeyAuth
 // @in                          header
@@ -54,50 +55,84 @@ func main() {
 	config, privateKey := initConfig()
 	logger := initLogger(config)
 
-	logger.Debug("Creating GitHub application installation configuration")
-	itr, err := ghinstallation.New(http.DefaultTransport, config.AppID, config.InstallationID, privateKey)
+	logger.Debug("Creating GitHub application configuration")
+	atr, err := ghinstallation.NewAppsTransport(http.DefaultTransport, config.AppID, privateKey)
 	if err != nil {
 		logger.Fatalf("Failed creating app authentication: %v", err)
 	}
-	logger.Debug("Created GitHub application installation configuration")
+	atr.BaseURL = config.GitHub.APIURL()
+	appClient, err := config.GitHub.NewClient(&http.Client{Transport: atr})
+	if err != nil {
+		logger.Fatalf("Failed creating GitHub application client: %v", err)
+	}
+	logger.Debug("Created GitHub application configuration")
+
+	logger.Info("Resolving GitHub application installations")
+	err = config.ResolveInstallations(context.Background(), appClient.Apps)
+	if err != nil {
+		logger.Fatalf("Failed resolving GitHub application installations: %v", err)
+	}
+	logger.Debug("Resolved GitHub application installations")
 
 	logger.Info("Initializing Rate Limiter")
 	lmt := tollbooth.NewLimiter(config.Server.RateLimit, &limiter.ExpirableOptions{DefaultExpirationTTL: time.Hour})
 	lmt.SetHeader("Authorization", []string{})
 	lmt.SetHeaderEntryExpirationTTL(time.Hour)
-	lmt.SetMessage(`{"code":429,"response":"You have reached maximum request limit. Please try again in a few seconds."}`)
-	lmt.SetMessageContentType("application/json")
+	lmt.SetMessage("You have reached maximum request limit. Please try again in a few seconds.")
 	logger.Debug("Initialized Rate Limiter")
 
+	var oidcVerifier *apis.OIDCVerifier
+	if config.OIDC.Enabled {
+		logger.Info("Initializing OIDC verifier")
+		oidcVerifier, err = apis.NewOIDCVerifier(config.OIDC, &http.Client{Timeout: 10 * time.Second})
+		if err != nil {
+			logger.Fatalf("Failed initializing OIDC verifier: %v", err)
+		}
+		logger.Debug("Initialized OIDC verifier")
+	}
+
+	logger.Info("Initializing audit log")
+	auditLog, err := apis.NewAuditSink(config.Audit)
+	if err != nil {
+		logger.Fatalf("Failed initializing audit log: %v", err)
+	}
+	logger.Debug("Initialized audit log")
+
+	logger.Debug("Initializing metrics")
+	metrics := apis.NewMetrics()
+	logger.Debug("Initialized metrics")
+
 	logger.Debug("Creating GitHub user client function")
+	userTransport := apis.NewRetryTransport(http.DefaultTransport, config.Server.Retry, metrics, "user")
 	createClientAndRetrieveUser := func(token, uuid string) (*apis.MaintainershipClient, *github.User, error) {
 		logger.WithField("uuid", uuid).Info("Creating GitHub user client")
-		ctx := context.Background()
+		ctx := context.WithValue(context.Background(), oauth2.HTTPClient, &http.Client{Transport: userTransport})
 		ts := oauth2.StaticTokenSource(
 			&oauth2.Token{AccessToken: token},
 		)
 		tc := oauth2.NewClient(ctx, ts)
-		client := github.NewClient(tc)
+		client, err := config.GitHub.NewClient(tc)
+		if err != nil {
+			logger.WithField("uuid", uuid).Errorf("Unable to create GitHub user client: %v", err)
+			return nil, nil, fmt.Errorf("unable to create GitHub user client: %w", err)
+		}
 		logger.WithField("uuid", uuid).Debug("Created GitHub user client")
 
 		logger.WithField("uuid", uuid).Info("Validating Authorization token")
-		user, _, err := client.Users.Get(context.Background(), "")
+		user, resp, err := client.Users.Get(context.Background(), "")
 		if err != nil {
 			logger.WithField("uuid", uuid).Errorf("Unable to verify authorization token authenticity: %v", err)
-			return nil, nil, fmt.Errorf("unable to verify authorization token authenticity: %w", err)
+			return nil, nil, apis.TokenError(resp, fmt.Errorf("unable to verify authorization token authenticity: %w", err))
 		}
 		lmt.SetBasicAuthUsers(append(lmt.GetBasicAuthUsers(), user.GetLogin()))
 		logger.WithField("uuid", uuid).Debug("Validated Authorization token")
 		return &apis.MaintainershipClient{
-			TeamsClient: client.Teams,
-			UsersClient: client.Users,
+			OrganizationsClient: client.Organizations,
+			TeamsClient:         client.Teams,
+			UsersClient:         client.Users,
 		}, user, nil
 	}
 
-	logger.Debug("Creating GitHub client")
-	client := github.NewClient(&http.Client{Transport: itr})
-	logger.Debug("Created GitHub client")
-
 	logger.Info("Initialize Router")
 	router := gin.New()
 	router.Use(requestid.New(requestid.Config{
@@ -110,11 +145,12 @@ func main() {
 
 	logger.Debug("Creating API manager")
 	manager := &apis.Manager{
-		ActionsClient:      client.Actions,
-		RepositoriesClient: client.Repositories,
-		TeamsClient:        client.Teams,
-		Router:             router,
-		Limit:              lmt,
+		AuditLog: auditLog,
+		Offline:  apis.NewOfflineRunners(),
+		OIDC:     oidcVerifier,
+		Router:   router,
+		Limit:    lmt,
+		Metrics:  metrics,
 		Server: &http.Server{
 			Addr:    net.JoinHostPort(config.Server.Address, strconv.Itoa(config.Server.Port)),
 			Handler: router,
@@ -123,11 +159,103 @@ func main() {
 		Logger:                     logger,
 		CreateMaintainershipClient: createClientAndRetrieveUser,
 	}
+	if config.Org != "" {
+		logger.Debugf("Creating GitHub client for organization %s", config.Org)
+		clients, err := initOrganizationClients(config, config.Org, config.InstallationID, privateKey, metrics)
+		if err != nil {
+			logger.Fatalf("Failed creating GitHub client for organization %s: %v", config.Org, err)
+		}
+		manager.ActionsClient = clients.ActionsClient
+		manager.RepositoriesClient = clients.RepositoriesClient
+		manager.RestClient = clients.RestClient
+		manager.TeamsClient = clients.TeamsClient
+		manager.TokenSource = clients.TokenSource
+		manager.Cache = clients.Cache
+		logger.Debugf("Created GitHub client for organization %s", config.Org)
+	}
+	for _, org := range config.Orgs {
+		logger.Debugf("Creating GitHub client for organization %s", org.Name)
+		clients, err := initOrganizationClients(config, org.Name, org.InstallationID, privateKey, metrics)
+		if err != nil {
+			logger.Fatalf("Failed creating GitHub client for organization %s: %v", org.Name, err)
+		}
+		manager.AddOrganization(org.Name, org.InstallationID, clients)
+		logger.Debugf("Created GitHub client for organization %s", org.Name)
+	}
 	logger.Debug("Created API manager")
 
+	if len(os.Args) > 1 && os.Args[1] == "migrate-group-names" {
+		migrateGroupNames(manager, logger, os.Args[2:])
+		return
+	}
 	manager.Serve()
 }
 
+// migrateGroupNames renames the runner groups named with a previous naming template to the configured naming template
+// and prints the renames as JSON
+func migrateGroupNames(manager *apis.Manager, logger *logrus.Logger, args []string) {
+	flags := flag.NewFlagSet("migrate-group-names", flag.ExitOnError)
+	from := flags.String("from", apis.DefaultGroupNameTemplate, "naming template the runner groups are currently named with")
+	dryRun := flags.Bool("dry-run", false, "report the runner groups that would be renamed without renaming them")
+	_ = flags.Parse(args)
+
+	logger.Infof("Migrating runner group names from %s", *from)
+	renames, err := manager.MigrateGroupNames(context.Background(), *from, *dryRun)
+	if renames == nil {
+		renames = []*apis.GroupRename{}
+	}
+	output, marshalErr := json.MarshalIndent(renames, "", "  ")
+	if marshalErr != nil {
+		logger.Fatalf("Unable to marshal runner group renames: %v", marshalErr)
+	}
+	fmt.Println(string(output))
+	if err != nil {
+		logger.Fatalf("Failed migrating runner group names: %v", err)
+	}
+	if failed := countFailedRenames(renames); failed > 0 {
+		logger.Fatalf("Failed renaming %d runner groups", failed)
+	}
+	logger.Debug("Migrated runner group names")
+}
+
+// countFailedRenames counts the runner groups the migration was unable to rename
+func countFailedRenames(renames []*apis.GroupRename) int {
+	var failed int
+	for _, rename := range renames {
+		if rename.Error != "" {
+			failed++
+		}
+	}
+	return failed
+}
+
+// initOrganizationClients creates the clients authenticated as the installation of the GitHub App in an organization
+func initOrganizationClients(config *apis.Config, org string, installationID int64, privateKey []byte, metrics *apis.Metrics) (*apis.OrganizationClients, error) {
+	transport := apis.NewRetryTransport(http.DefaultTransport, config.Server.Retry, metrics, "installation")
+	itr, err := ghinstallation.New(transport, config.AppID, installationID, privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create app authentication: %w", err)
+	}
+	itr.BaseURL = config.GitHub.APIURL()
+	client, err := config.GitHub.NewClient(&http.Client{Transport: itr})
+	if err != nil {
+		return nil, err
+	}
+
+	var cache *apis.MaintainershipCache
+	if config.Server.Cache.TTL > 0 || config.Server.Cache.NegativeTTL > 0 {
+		cache = apis.NewMaintainershipCache(config.Server.Cache.TTL, config.Server.Cache.NegativeTTL)
+	}
+	return &apis.OrganizationClients{
+		ActionsClient:      &apis.InstrumentedActionsClient{Client: client.Actions, Metrics: metrics, Org: org},
+		RepositoriesClient: &apis.InstrumentedRepositoriesClient{Client: client.Repositories, Metrics: metrics, Org: org},
+		RestClient:         client,
+		TeamsClient:        &apis.InstrumentedTeamsClient{Client: client.Teams, Metrics: metrics, Org: org},
+		TokenSource:        itr,
+		Cache:              cache,
+	}, nil
+}
+
 func initConfig() (*apis.Config, []byte) {
 	var bytes []byte
 	var err error
@@ -158,6 +286,31 @@ func initConfig() (*apis.Config, []byte) {
 		}
 	}
 
+	if err := config.ValidateOrgs(); err != nil {
+		logrus.Fatalf("Invalid organization configuration: %v", err)
+	}
+	if err := config.GitHub.Validate(); err != nil {
+		logrus.Fatalf("Invalid GitHub configuration: %v", err)
+	}
+	if err := config.Policy.Validate(); err != nil {
+		logrus.Fatalf("Invalid policy configuration: %v", err)
+	}
+	if err := config.OIDC.Validate(); err != nil {
+		logrus.Fatalf("Invalid OIDC configuration: %v", err)
+	}
+	if err := config.Reconcile.Validate(); err != nil {
+		logrus.Fatalf("Invalid reconcile configuration: %v", err)
+	}
+	if err := config.Janitor.Validate(); err != nil {
+		logrus.Fatalf("Invalid janitor configuration: %v", err)
+	}
+	if err := config.Groups.Validate(); err != nil {
+		logrus.Fatalf("Invalid groups configuration: %v", err)
+	}
+	if err := config.Server.Retry.Validate(); err != nil {
+		logrus.Fatalf("Invalid retry configuration: %v", err)
+	}
+
 	if config.Logging.Level == "" {
 		config.Logging.Level = "info"
 	}